@@ -0,0 +1,250 @@
+package cassandra
+
+// #cgo LDFLAGS: -L/usr/local/lib -lcassandra
+// #cgo CFLAGS: -I/usr/local/include
+// #include <stdlib.h>
+// #include <cassandra.h>
+import "C"
+import (
+	"fmt"
+	"golang-driver/cassandra/internal/cqltext"
+	"strings"
+)
+
+type BatchType int
+
+const (
+	LoggedBatch BatchType = iota
+	UnloggedBatch
+	CounterBatch
+)
+
+func (bt BatchType) toC() C.CassBatchType {
+	switch bt {
+	case UnloggedBatch:
+		return C.CASS_BATCH_TYPE_UNLOGGED
+	case CounterBatch:
+		return C.CASS_BATCH_TYPE_COUNTER
+	default:
+		return C.CASS_BATCH_TYPE_LOGGED
+	}
+}
+
+// A Batch groups several statements executed as a single request.
+// Counter updates can only be grouped in a CounterBatch.
+// The *Batch **must** be Close() once done.
+type Batch struct {
+	cptr              *C.struct_CassBatch_
+	session           *Session
+	consistency       Consistency
+	serialConsistency Consistency
+	size              int
+	err               error
+}
+
+// Returns a new empty batch of the given type.
+func (session *Session) NewBatch(batchType BatchType) *Batch {
+	batch := new(Batch)
+	batch.cptr = C.cass_batch_new(batchType.toC())
+	batch.session = session
+	batch.consistency = unset
+	batch.serialConsistency = unset
+	return batch
+}
+
+// Adds a statement to the batch. The batch keeps its own reference to
+// the statement, so the statement can be closed once added.
+func (batch *Batch) Add(stmt *Statement) *Batch {
+	if batch.err != nil {
+		return batch
+	}
+	if stmt.err != nil {
+		batch.err = stmt.err
+		return batch
+	}
+	retc := C.cass_batch_add_statement(batch.cptr, stmt.cptr)
+	if retc != C.CASS_OK {
+		batch.err = newError(retc)
+		return batch
+	}
+	batch.size++
+	return batch
+}
+
+// Adds a simple statement with the given query and bound values.
+func (batch *Batch) Query(query string, args ...interface{}) *Batch {
+	if batch.err != nil {
+		return batch
+	}
+	stmt, err := batch.session.Query(query, args...)
+	if err != nil {
+		batch.err = err
+		return batch
+	}
+	defer stmt.Close()
+
+	return batch.Add(stmt)
+}
+
+// Returns the number of statements in the batch.
+func (batch *Batch) Size() int {
+	return batch.size
+}
+
+func (batch *Batch) WithConsistency(c Consistency) *Batch {
+	batch.consistency = c
+	return batch
+}
+
+func (batch *Batch) WithSerialConsistency(c Consistency) *Batch {
+	batch.serialConsistency = c
+	return batch
+}
+
+func (batch *Batch) Close() {
+	C.cass_batch_free(batch.cptr)
+	batch.cptr = nil
+}
+
+// Executes the batch. The returned *Rows are empty unless the batch
+// contains conditional updates, in which case they tell whether they
+// were applied.
+func (batch *Batch) Exec() (*Rows, error) {
+	future := batch.ExecAsync()
+	defer future.Close()
+
+	if err := future.Error(); err != nil {
+		return nil, err
+	}
+	return future.Result(), nil
+}
+
+func (batch *Batch) ExecAsync() *Future {
+	if err := batch.applyOptions(); err != nil {
+		return &Future{err: err}
+	}
+
+	warnings := inflight.track(batch.session)
+	future := async(func() *C.struct_CassFuture_ {
+		return C.cass_session_execute_batch(batch.session.cptr, batch.cptr)
+	})
+	future.session = batch.session
+	future.warnings = warnings
+
+	return future
+}
+
+func (batch *Batch) applyOptions() error {
+	if batch.err != nil {
+		return batch.err
+	}
+	if batch.consistency != unset {
+		retc := C.cass_batch_set_consistency(batch.cptr, batch.consistency.toC())
+		if retc != C.CASS_OK {
+			return newError(retc)
+		}
+	}
+	if batch.serialConsistency != unset {
+		retc := C.cass_batch_set_serial_consistency(batch.cptr, batch.serialConsistency.toC())
+		if retc != C.CASS_OK {
+			return newError(retc)
+		}
+	}
+	return nil
+}
+
+// CounterUpdate collects increments and decrements of the counter
+// columns of a table and applies them in a single counter batch.
+type CounterUpdate struct {
+	session     *Session
+	table       string
+	keyColumns  []string
+	consistency Consistency
+	updates     []counterDelta
+	err         error
+}
+
+type counterDelta struct {
+	column    string
+	delta     int64
+	keyValues []interface{}
+}
+
+// Returns a CounterUpdate for the counters of the given table, which
+// may be qualified by its keyspace. The rows are identified by the
+// values of keyColumns, which must list the whole primary key. The
+// names of the table and of the columns are the ones of the schema
+// (e.g. Hits for a column created as "Hits"): they're quoted as needed.
+func (session *Session) CounterUpdate(table string, keyColumns ...string) *CounterUpdate {
+	return &CounterUpdate{
+		session:     session,
+		table:       table,
+		keyColumns:  keyColumns,
+		consistency: unset,
+	}
+}
+
+// Adds delta to the counter column of the row with the given key values.
+func (cu *CounterUpdate) Increment(column string, delta int64, keyValues ...interface{}) *CounterUpdate {
+	if len(keyValues) != len(cu.keyColumns) {
+		if cu.err == nil {
+			cu.err = fmt.Errorf("counter update of %s.%s needs %d key values, got %d",
+				cu.table, column, len(cu.keyColumns), len(keyValues))
+		}
+		return cu
+	}
+	cu.updates = append(cu.updates, counterDelta{column, delta, keyValues})
+	return cu
+}
+
+// Subtracts delta from the counter column of the row with the given key values.
+func (cu *CounterUpdate) Decrement(column string, delta int64, keyValues ...interface{}) *CounterUpdate {
+	return cu.Increment(column, -delta, keyValues...)
+}
+
+func (cu *CounterUpdate) WithConsistency(c Consistency) *CounterUpdate {
+	cu.consistency = c
+	return cu
+}
+
+// Returns the number of pending updates.
+func (cu *CounterUpdate) Size() int {
+	return len(cu.updates)
+}
+
+// Applies the pending updates in a counter batch. Once executed
+// successfully the CounterUpdate is empty and can be reused.
+func (cu *CounterUpdate) Exec() error {
+	if cu.err != nil {
+		return cu.err
+	}
+	if len(cu.updates) == 0 {
+		return nil
+	}
+
+	batch := cu.session.NewBatch(CounterBatch).WithConsistency(cu.consistency)
+	defer batch.Close()
+
+	for _, u := range cu.updates {
+		args := append([]interface{}{u.delta}, u.keyValues...)
+		batch.Query(cu.query(u.column), args...)
+	}
+	rows, err := batch.Exec()
+	if err != nil {
+		return err
+	}
+	rows.Close()
+	cu.updates = nil
+	return nil
+}
+
+// the names are quoted as needed, e.g. "Hits" or "order"
+func (cu *CounterUpdate) query(column string) string {
+	conditions := make([]string, len(cu.keyColumns))
+	for i, key := range cu.keyColumns {
+		conditions[i] = cqltext.QuoteIdentifier(key) + " = ?"
+	}
+	column = cqltext.QuoteIdentifier(column)
+	return fmt.Sprintf("UPDATE %s SET %s = %s + ? WHERE %s",
+		cqltext.QuoteName(cu.table), column, column, strings.Join(conditions, " AND "))
+}
@@ -0,0 +1,90 @@
+package cassandra_test
+
+import (
+	"database/sql/driver"
+	"golang-driver/cassandra"
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+// a driver.Valuer returning its own type, which would be converted
+// forever
+type recursiveValuer string
+
+func (v recursiveValuer) Value() (driver.Value, error) {
+	return v, nil
+}
+
+type hits int
+
+// The values are converted for binding like for CQL literals: the
+// literals show the type and the value a Go value is bound as.
+func TestBindConversions(t *testing.T) {
+	cassandra.RegisterCodec(reflect.TypeOf(Money(0)), moneyCodec{})
+	defer cassandra.RegisterCodec(reflect.TypeOf(Money(0)), nil)
+	cassandra.RegisterCodec(reflect.TypeOf(netip.Addr{}), addrCodec{})
+	defer cassandra.RegisterCodec(reflect.TypeOf(netip.Addr{}), nil)
+
+	random, _ := cassandra.ParseUUID("f0d07136-62f9-4d18-a6ce-cd5f4beb4348")
+	named, _ := cassandra.ParseUUID("6fa459ea-ee8a-3ca4-894e-db77e160355e")
+	id := AccountID{random}
+
+	tests := []struct {
+		value    interface{}
+		kind     cassandra.CassType
+		expected string
+	}{
+		// codecs, which give the type when it's unknown
+		{Money(1250), cassandra.CUnknown, "1250"},
+		{Money(1250), cassandra.CBigInt, "1250"},
+		{netip.MustParseAddr("10.0.0.1"), cassandra.CUnknown, "'10.0.0.1'"},
+		{netip.Addr{}, cassandra.CUnknown, "null"},
+		{(*netip.Addr)(nil), cassandra.CInet, "null"},
+		{[]Money{1, 2}, cassandra.CList.Specialize(cassandra.CBigInt), "[1, 2]"},
+		// the Null* types
+		{cassandra.NullInt64{Int64: 5, Valid: true}, cassandra.CBigInt, "5"},
+		{cassandra.NullInt64{Int64: 5}, cassandra.CBigInt, "null"},
+		{(*cassandra.NullString)(nil), cassandra.CText, "null"},
+		{&cassandra.NullBool{Bool: true, Valid: true}, cassandra.CUnknown, "true"},
+		// driver.Valuer and encoding.TextMarshaler
+		{Email{"jane", "example.com"}, cassandra.CUnknown, "'jane@example.com'"},
+		{(*Email)(nil), cassandra.CText, "null"},
+		{id, cassandra.CUuid, "f0d07136-62f9-4d18-a6ce-cd5f4beb4348"},
+		{id, cassandra.CUnknown, "'f0d07136-62f9-4d18-a6ce-cd5f4beb4348'"},
+		{(*AccountID)(nil), cassandra.CUuid, "null"},
+		// the types inferred from the Go values
+		{named, cassandra.CUnknown, "6fa459ea-ee8a-3ca4-894e-db77e160355e"},
+		{int(1 << 40), cassandra.CUnknown, "1099511627776"},
+		{int(-7), cassandra.CUnknown, "-7"},
+		{hits(3), cassandra.CUnknown, "3"},
+		{int(7), cassandra.CInt, "7"},
+		{hits(7), cassandra.CInt, "7"},
+	}
+	for _, test := range tests {
+		literal, err := test.kind.Literal(test.value)
+		if err != nil {
+			t.Errorf("%v (%T as %s): %s", test.value, test.value, test.kind.String(), err)
+		} else if literal != test.expected {
+			t.Errorf("%v (%T as %s): %s != %s (expected)", test.value, test.value,
+				test.kind.String(), literal, test.expected)
+		}
+	}
+
+	errors := []struct {
+		value interface{}
+		kind  cassandra.CassType
+	}{
+		{recursiveValuer("a"), cassandra.CText},
+		{int(1 << 40), cassandra.CInt},
+		{hits(-1 << 40), cassandra.CInt},
+		{cassandra.NullString{String: "x", Valid: true}, cassandra.CInt},
+		{struct{}{}, cassandra.CUnknown},
+	}
+	for _, test := range errors {
+		if literal, err := test.kind.Literal(test.value); err == nil {
+			t.Errorf("%v (%T as %s): expected an error, got %s", test.value, test.value,
+				test.kind.String(), literal)
+		}
+	}
+}
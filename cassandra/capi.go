@@ -4,14 +4,31 @@ package cassandra
 // #cgo CFLAGS: -I/usr/local/include
 // #include <stdlib.h>
 // #include <cassandra.h>
+//
+// extern void goLogCallback(CassLogMessage* message, void* data);
+//
+// static void set_go_log_callback() {
+// 	cass_log_set_callback((CassLogCallback)goLogCallback, NULL);
+// }
 import "C"
 import (
 	"errors"
 	"fmt"
 	"math/big"
 	"reflect"
+	"sync"
 )
 
+var installLogCallbackOnce sync.Once
+
+// Replaces the C driver's logger with goLogCallback (see warnings.go).
+// Must be called before any other C driver function that may log.
+func installLogCallback() {
+	installLogCallbackOnce.Do(func() {
+		C.set_go_log_callback()
+	})
+}
+
 // Private API for CassType
 func newCassType(kind int, subTypes ...int) CassType {
 	ctype := new(CassType)
@@ -38,12 +55,14 @@ func cassTypeFromCassDataType(cdt cassDataType) CassType {
 		ctype.primary = int(cvt)
 		ctype.subtypes = make([]CassType, 1)
 		ctype.subtypes[0] = cassTypeFromCassDataType(C.cass_data_type_sub_data_type(cdt, 0))
+		ctype.frozen = C.cass_data_type_is_frozen(cdt) == C.cass_true
 
 		return *ctype
 
 	case CASS_VALUE_TYPE_MAP:
 		ctype := new(CassType)
 		ctype.primary = CASS_VALUE_TYPE_MAP
+		ctype.frozen = C.cass_data_type_is_frozen(cdt) == C.cass_true
 		ctype.subtypes = make([]CassType, 2)
 		for i, _ := range ctype.subtypes {
 			ctype.subtypes[i] = cassTypeFromCassDataType(
@@ -52,6 +71,7 @@ func cassTypeFromCassDataType(cdt cassDataType) CassType {
 
 		return *ctype
 
+	// tuples are always frozen so it's left implicit
 	case CASS_VALUE_TYPE_TUPLE:
 		ctype := new(CassType)
 		ctype.primary = CASS_VALUE_TYPE_TUPLE
@@ -63,9 +83,39 @@ func cassTypeFromCassDataType(cdt cassDataType) CassType {
 
 		return *ctype
 
-	// incomplete for now
 	case CASS_VALUE_TYPE_UDT:
-		return CUdt
+		ctype := new(CassType)
+		ctype.primary = CASS_VALUE_TYPE_UDT
+		ctype.frozen = C.cass_data_type_is_frozen(cdt) == C.cass_true
+		var str *C.char
+		var length C.size_t
+		if C.cass_data_type_keyspace(cdt, &str, &length) == C.CASS_OK {
+			ctype.keyspace = C.GoStringN(str, C.int(length))
+		}
+		if C.cass_data_type_type_name(cdt, &str, &length) == C.CASS_OK {
+			ctype.name = C.GoStringN(str, C.int(length))
+		}
+		count := int(C.cass_data_type_sub_type_count(cdt))
+		ctype.fieldNames = make([]string, count)
+		ctype.subtypes = make([]CassType, count)
+		for i := 0; i < count; i++ {
+			if C.cass_data_type_sub_type_name(cdt, C.size_t(i), &str, &length) == C.CASS_OK {
+				ctype.fieldNames[i] = C.GoStringN(str, C.int(length))
+			}
+			ctype.subtypes[i] = cassTypeFromCassDataType(
+				C.cass_data_type_sub_data_type(cdt, C.size_t(i)))
+		}
+
+		return *ctype
+
+	case CASS_VALUE_TYPE_CUSTOM:
+		ctype := newCassType(CASS_VALUE_TYPE_CUSTOM)
+		var class *C.char
+		var length C.size_t
+		if C.cass_data_type_class_name(cdt, &class, &length) == C.CASS_OK {
+			ctype.class = C.GoStringN(class, C.int(length))
+		}
+		return ctype
 
 	case CASS_VALUE_TYPE_ASCII:
 		return CAscii
@@ -75,6 +125,8 @@ func cassTypeFromCassDataType(cdt cassDataType) CassType {
 		return CVarchar
 	case CASS_VALUE_TYPE_BIGINT:
 		return CBigInt
+	case CASS_VALUE_TYPE_COUNTER:
+		return CCounter
 	case CASS_VALUE_TYPE_INT:
 		return CInt
 	case CASS_VALUE_TYPE_SMALL_INT:
@@ -105,16 +157,26 @@ func cassTypeFromCassDataType(cdt cassDataType) CassType {
 		return CTimeuuid
 	case CASS_VALUE_TYPE_INET:
 		return CInet
-	// counter, custom
+	case CASS_VALUE_TYPE_DURATION:
+		return CDuration
 	default:
 		return newCassType(int(cvt))
 	}
 }
 
 func (ct CassType) Equals(other CassType) bool {
-	if ct.primary != other.primary {
+	if ct.primary != other.primary || ct.frozen != other.frozen {
 		return false
 	}
+	if ct.keyspace != other.keyspace || ct.name != other.name ||
+		ct.class != other.class || len(ct.fieldNames) != len(other.fieldNames) {
+		return false
+	}
+	for idx := range ct.fieldNames {
+		if ct.fieldNames[idx] != other.fieldNames[idx] {
+			return false
+		}
+	}
 	if len(ct.subtypes) != len(other.subtypes) {
 		return false
 	}
@@ -159,6 +221,7 @@ const (
 	CASS_VALUE_TYPE_TIME      = 0x0012
 	CASS_VALUE_TYPE_SMALL_INT = 0x0013
 	CASS_VALUE_TYPE_TINY_INT  = 0x0014
+	CASS_VALUE_TYPE_DURATION  = 0x0015
 	CASS_VALUE_TYPE_LIST      = 0x0020
 	CASS_VALUE_TYPE_MAP       = 0x0021
 	CASS_VALUE_TYPE_SET       = 0x0022
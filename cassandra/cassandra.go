@@ -5,16 +5,62 @@ package cassandra
 // #include <stdlib.h>
 // #include <cassandra.h>
 import "C"
-import "unsafe"
-
-import "errors"
+import (
+	"errors"
+	"net"
+	"sync"
+	"unsafe"
+)
 
 type Session struct {
-	cptr    *C.struct_CassSession_
-	Cluster *Cluster
+	cptr           *C.struct_CassSession_
+	Cluster        *Cluster
+	stmtCache      *preparedCache
+	handlerMu      sync.Mutex
+	warningHandler func(warning string)
+	// the keyspace set by ConnectKeyspace or the last USE statement,
+	// which keys the prepared statement cache
+	keyspaceMu sync.Mutex
+	keyspace   string
+}
+
+// Registers a function that is called with every warning (e.g. "batch
+// too large") returned by the server while requests executed through
+// this session are in flight. The C/C++ driver reports the warnings
+// only through its logger: the handler is called once per logged
+// warning, and may receive the warnings of the requests of other
+// sessions executed concurrently. The handlers of all the sessions are
+// called in turn from a single goroutine, after the warning was
+// logged, so a handler must not block (e.g. by waiting for a request).
+func (session *Session) SetWarningHandler(handler func(warning string)) {
+	session.handlerMu.Lock()
+	session.warningHandler = handler
+	session.handlerMu.Unlock()
+}
+
+func (session *Session) handler() func(warning string) {
+	session.handlerMu.Lock()
+	defer session.handlerMu.Unlock()
+	return session.warningHandler
+}
+
+func (session *Session) currentKeyspace() string {
+	session.keyspaceMu.Lock()
+	defer session.keyspaceMu.Unlock()
+	return session.keyspace
+}
+
+func (session *Session) setKeyspace(keyspace string) {
+	session.keyspaceMu.Lock()
+	session.keyspace = keyspace
+	session.keyspaceMu.Unlock()
 }
 
 func (session *Session) Close() {
+	if session.stmtCache != nil {
+		session.stmtCache.purge()
+		session.stmtCache = nil
+	}
 	C.cass_session_free(session.cptr)
 	session.cptr = nil
 	session.Cluster = nil
@@ -47,7 +93,16 @@ func (session *Session) Query(query string, args ...interface{}) (*Statement, er
 
 // Executes the given query asynchronously and returns a *Future
 // that can be used to retrieve the results (or error).
+// If the prepared statement cache is enabled (see
+// EnablePreparedStatementCache) the query is prepared first.
 func (session *Session) ExecAsync(query string, args ...interface{}) *Future {
+	if session.stmtCache != nil {
+		return session.execCachedAsync(query, args...)
+	}
+	return session.execAsync(query, args...)
+}
+
+func (session *Session) execAsync(query string, args ...interface{}) *Future {
 	stmt := newSimpleStatement(session, query, len(args))
 	defer stmt.Close()
 
@@ -75,6 +130,7 @@ func (session *Session) Prepare(query string) (*PreparedStatement, error) {
 	pstmt := new(PreparedStatement)
 	pstmt.cptr = C.cass_future_get_prepared(future.cptr)
 	pstmt.session = session
+	pstmt.query = query
 	pstmt.consistency = unset
 	pstmt.serialConsistency = unset
 
@@ -130,6 +186,7 @@ func (c Consistency) toC() C.CassConsistency {
 type PreparedStatement struct {
 	cptr              *C.struct_CassPrepared_
 	session           *Session
+	query             string
 	consistency       Consistency
 	serialConsistency Consistency
 	pagingSize        int
@@ -143,6 +200,35 @@ func (pstmt *PreparedStatement) SetSerialConsistency(c Consistency) {
 	pstmt.serialConsistency = c
 }
 
+// Sets the number of rows fetched per page (see
+// Statement.WithPagingSize).
+func (pstmt *PreparedStatement) SetPagingSize(size int) {
+	pstmt.pagingSize = size
+}
+
+// Returns the names of the bind markers of the statement: the names
+// of named markers (e.g. id for :id) and the names of the columns
+// positional markers are bound to.
+func (pstmt *PreparedStatement) ParameterNames() []string {
+	var names []string
+	for i := 0; ; i++ {
+		var cName *C.char
+		var size C.size_t
+		retc := C.cass_prepared_parameter_name(pstmt.cptr, C.size_t(i), &cName, &size)
+		if retc != C.CASS_OK {
+			return names
+		}
+		names = append(names, C.GoStringN(cName, C.int(size)))
+	}
+}
+
+// Returns the type of the value bound to the marker at the given index.
+func (pstmt *PreparedStatement) ParameterType(index int) CassType {
+	return cassTypeFromCassDataType(
+		cassDataType(C.cass_prepared_parameter_data_type(pstmt.cptr,
+			C.size_t(index))))
+}
+
 func (pstmt *PreparedStatement) Close() {
 	C.cass_prepared_free(pstmt.cptr)
 	pstmt.cptr = nil
@@ -165,6 +251,7 @@ func (pstmt *PreparedStatement) ExecAsync(args ...interface{}) *Future {
 
 	stmt.WithConsistency(pstmt.consistency)
 	stmt.WithSerialConsistency(pstmt.serialConsistency)
+	stmt.WithPagingSize(pstmt.pagingSize)
 
 	if err := stmt.bind(args...); err != nil {
 		return &Future{err: err}
@@ -176,7 +263,8 @@ func (pstmt *PreparedStatement) ExecAsync(args ...interface{}) *Future {
 func (pstmt *PreparedStatement) Query(args ...interface{}) (*Statement, error) {
 	stmt := newBoundStatement(pstmt)
 	stmt.WithConsistency(pstmt.consistency)
-	stmt.WithSerialConsistency(pstmt.consistency)
+	stmt.WithSerialConsistency(pstmt.serialConsistency)
+	stmt.WithPagingSize(pstmt.pagingSize)
 
 	if err := stmt.bind(args...); err != nil {
 		return nil, err
@@ -186,8 +274,15 @@ func (pstmt *PreparedStatement) Query(args ...interface{}) (*Statement, error) {
 }
 
 type Future struct {
-	cptr *C.struct_CassFuture_
-	err  error
+	cptr     *C.struct_CassFuture_
+	err      error
+	session  *Session
+	warnings *warningCollector
+	// the statement the Rows fetch the next pages with
+	stmt *Statement
+	// the keyspace of a USE statement, which becomes the session's
+	// once it succeeded
+	usedKeyspace *string
 }
 
 func (future *Future) Error() error {
@@ -196,6 +291,7 @@ func (future *Future) Error() error {
 		return future.err
 	}
 	if C.cass_future_error_code(future.cptr) == C.CASS_OK {
+		future.switchKeyspace()
 		return nil
 	}
 	var msg *C.char
@@ -205,12 +301,104 @@ func (future *Future) Error() error {
 	return errors.New(C.GoStringN(msg, C.int(sizet)))
 }
 
+// records the keyspace of a successful USE statement in the session
+func (future *Future) switchKeyspace() {
+	if future.usedKeyspace != nil {
+		future.session.setKeyspace(*future.usedKeyspace)
+		future.usedKeyspace = nil
+	}
+}
+
 func (future *Future) Result() *Rows {
+	if C.cass_future_error_code(future.cptr) == C.CASS_OK {
+		future.switchKeyspace()
+	}
 	rows := new(Rows)
 	rows.cptr = C.cass_future_get_result(future.cptr)
+	rows.customPayload = future.CustomPayload()
+	rows.tracingId, rows.traced = future.TracingID()
+	rows.warnings = future.collectWarnings()
+	rows.coordinator = future.Coordinator()
+	rows.stmt, future.stmt = future.stmt, nil
 	return rows
 }
 
+// Returns the address of the node that coordinated the request
+// or nil if it's not known.
+func (future *Future) Coordinator() net.IP {
+	if future.err != nil {
+		return nil
+	}
+	node := C.cass_future_coordinator(future.cptr)
+	if node == nil {
+		return nil
+	}
+	var inet C.struct_CassInet_
+	if C.cass_node_get_address(node, &inet) != C.CASS_OK {
+		return nil
+	}
+	ip := make([]byte, int(inet.address_length))
+	for i := range ip {
+		ip[i] = byte(inet.address[i])
+	}
+	return net.IP(ip)
+}
+
+// stops collecting warnings for this request (the session's warning
+// handler was queued when they were logged)
+func (future *Future) collectWarnings() []string {
+	if future.warnings == nil {
+		return nil
+	}
+	warnings := inflight.untrack(future.warnings)
+	future.warnings = nil
+	return warnings
+}
+
+// Returns the id of the trace session if tracing was enabled for
+// the request.
+func (future *Future) TracingID() (UUID, bool) {
+	if future.err != nil {
+		return UUID{}, false
+	}
+	var cuuid C.CassUuid
+	if C.cass_future_tracing_id(future.cptr, &cuuid) != C.CASS_OK {
+		return UUID{}, false
+	}
+	id, err := uuidFromC(cuuid)
+	if err != nil {
+		return UUID{}, false
+	}
+	return id, true
+}
+
+// Returns the custom payload sent back by the server with the
+// response (protocol version 4 or newer) or nil if there was none.
+func (future *Future) CustomPayload() map[string][]byte {
+	if future.err != nil {
+		return nil
+	}
+	count := int(C.cass_future_custom_payload_item_count(future.cptr))
+	if count == 0 {
+		return nil
+	}
+	payload := make(map[string][]byte, count)
+	for i := 0; i < count; i++ {
+		var name *C.char
+		var nameLen C.size_t
+		var value *C.cass_byte_t
+		var valueLen C.size_t
+		retc := C.cass_future_custom_payload_item(future.cptr, C.size_t(i),
+			&name, &nameLen, &value, &valueLen)
+		if retc != C.CASS_OK {
+			continue
+		}
+		payload[C.GoStringN(name, C.int(nameLen))] =
+			C.GoBytes(unsafe.Pointer(value), C.int(valueLen))
+	}
+	return payload
+}
+
 func (future *Future) Wait() {
 	C.cass_future_wait(future.cptr)
 }
@@ -220,26 +408,82 @@ func (future *Future) WaitTimed(timeout uint64) bool {
 }
 
 func (future *Future) Close() {
+	if future.stmt != nil {
+		future.stmt.donePaging()
+		future.stmt = nil
+	}
 	if future.err != nil {
 		return
 	}
+	if future.warnings != nil {
+		inflight.untrack(future.warnings)
+		future.warnings = nil
+	}
 	C.cass_future_free(future.cptr)
 	future.cptr = nil
 }
 
 type Rows struct {
-	iter *C.struct_CassIterator_
-	cptr *C.struct_CassResult_
-	err  error
+	iter          *C.struct_CassIterator_
+	cptr          *C.struct_CassResult_
+	customPayload map[string][]byte
+	tracingId     UUID
+	traced        bool
+	warnings      []string
+	coordinator   net.IP
+	err           error
+	// the statement fetching the next pages, if paging is enabled
+	stmt *Statement
+	// the index of each column by name
+	columns map[string]int
 }
 
+// Returns the error that ended the iteration of the rows, if any
+// (e.g. a failure to fetch the next page).
 func (r *Rows) Err() error {
 	return r.err
 }
 
 func (rows *Rows) Close() {
-	C.cass_result_free(rows.cptr)
-	rows.cptr = nil
+	if rows.iter != nil {
+		C.cass_iterator_free(rows.iter)
+		rows.iter = nil
+	}
+	if rows.cptr != nil {
+		C.cass_result_free(rows.cptr)
+		rows.cptr = nil
+	}
+	if rows.stmt != nil {
+		rows.stmt.donePaging()
+		rows.stmt = nil
+	}
+}
+
+// Returns the custom payload the server attached to the response
+// (protocol version 4 or newer) or nil if there was none.
+func (rows *Rows) CustomPayload() map[string][]byte {
+	return rows.customPayload
+}
+
+// Returns the id of the trace session if tracing was enabled
+// for the statement (see Statement.WithTracing).
+func (rows *Rows) TracingID() (UUID, bool) {
+	return rows.tracingId, rows.traced
+}
+
+// Returns the warnings the server sent back with the response.
+//
+// The C/C++ driver reports server warnings only through its logger,
+// so a warning is attributed to every request that was in flight when
+// it was logged. Under concurrent load a warning may show up on more
+// than one result.
+func (rows *Rows) Warnings() []string {
+	return rows.warnings
+}
+
+// Returns the address of the node that coordinated the request.
+func (rows *Rows) Coordinator() net.IP {
+	return rows.coordinator
 }
 
 func (rows *Rows) ColumnCount() uint64 {
@@ -260,30 +504,44 @@ func (rows *Rows) ColumnType(index int) CassType {
 	return cassTypeFromCassDataType(C.cass_result_column_data_type(rows.cptr, C.size_t(index)))
 }
 
+// Advances to the next row, fetching the next page first if paging is
+// enabled and the rows of the current page have all been read.
+// Returns false when there are no more rows or an error occurred (see
+// Err).
 func (rows *Rows) Next() bool {
+	if rows.cptr == nil || rows.err != nil {
+		return false
+	}
 	if rows.iter == nil {
 		rows.iter = C.cass_iterator_from_result(rows.cptr)
 	}
-	return C.cass_iterator_next(rows.iter) != 0
+	for C.cass_iterator_next(rows.iter) == 0 {
+		if !rows.fetchNextPage() {
+			return false
+		}
+	}
+	return true
 }
 
-func (rows *Rows) Scan(args ...interface{}) error {
-	if rows.ColumnCount() < uint64(len(args)) {
-		return errors.New("invalid argument count")
+// replaces the current result with the next page; returns false if
+// there's none or it couldn't be fetched
+func (rows *Rows) fetchNextPage() bool {
+	if rows.stmt == nil || C.cass_result_has_more_pages(rows.cptr) == 0 {
+		return false
 	}
-
-	row := C.cass_iterator_get_row(rows.iter)
-
-	for i, v := range args {
-		pos := C.size_t(i)
-		value := C.cass_row_get_column(row, pos)
-		ctype := cassTypeFromCassDataType(
-			C.cass_result_column_data_type(rows.cptr, pos))
-
-		if _, err := read(value, ctype, v); err != nil {
-			return newColumnError(rows, i, v, err)
-		}
+	future, err := rows.stmt.execNextPage(rows.cptr)
+	if err != nil {
+		rows.err = err
+		return false
 	}
+	defer future.Close()
+	C.cass_iterator_free(rows.iter)
+	C.cass_result_free(rows.cptr)
+	rows.cptr = C.cass_future_get_result(future.cptr)
+	rows.iter = C.cass_iterator_from_result(rows.cptr)
+	return true
+}
 
-	return nil
+func (rows *Rows) Scan(args ...interface{}) error {
+	return rows.row().Scan(args...)
 }
@@ -0,0 +1,329 @@
+package cassandra
+
+import (
+	"fmt"
+	"golang-driver/cassandra/internal/cqltext"
+	"reflect"
+	"strings"
+)
+
+// Returns the keyspace of a UDT type (if known).
+func (ct CassType) Keyspace() string {
+	return ct.keyspace
+}
+
+// Returns the name of a UDT type (if known).
+func (ct CassType) Name() string {
+	return ct.name
+}
+
+// Returns the names of the fields of a UDT type, in the same order
+// as their types (see Subtypes).
+func (ct CassType) FieldNames() []string {
+	return ct.fieldNames
+}
+
+// Returns the Java class implementing a custom type.
+func (ct CassType) Class() string {
+	return ct.class
+}
+
+// Returns the types of the elements of a collection or tuple, or
+// of the fields of a UDT.
+func (ct CassType) Subtypes() []CassType {
+	return ct.subtypes
+}
+
+// Returns the Go type values of this type are read into when
+// scanning an interface{} (e.g. string for text, []int for list<int>).
+func (ct CassType) GoType() (reflect.Type, error) {
+	return goTypeFor(ct)
+}
+
+// UDTs are represented as in CQL (e.g. ks.address) followed, when
+// the fields are known, by their definition as in CREATE TYPE
+// (e.g. ks.address(street text, zip int)).
+func (ct CassType) udtString() string {
+	if ct.name == "" {
+		return "udt"
+	}
+	name := cqltext.QuoteIdentifier(ct.name)
+	if _, reserved := typeKeywords[ct.name]; reserved && ct.keyspace == "" {
+		// would be parsed as the built-in type
+		name = `"` + ct.name + `"`
+	}
+	if ct.keyspace != "" {
+		name = cqltext.QuoteIdentifier(ct.keyspace) + "." + name
+	}
+	if len(ct.fieldNames) == 0 {
+		return name
+	}
+	fields := make([]string, len(ct.fieldNames))
+	for i, f := range ct.fieldNames {
+		fields[i] = cqltext.QuoteIdentifier(f) + " " + ct.subtypes[i].String()
+	}
+	return fmt.Sprintf("%s(%s)", name, strings.Join(fields, ", "))
+}
+
+// the names that cannot be used unquoted for UDTs
+var typeKeywords = map[string]struct{}{
+	"frozen": {}, "list": {}, "set": {}, "map": {}, "tuple": {},
+}
+
+func init() {
+	for name := range nativeTypes {
+		typeKeywords[name] = struct{}{}
+	}
+}
+
+var nativeTypes = map[string]CassType{
+	"ascii":     CAscii,
+	"bigint":    CBigInt,
+	"blob":      CBlob,
+	"boolean":   CBoolean,
+	"counter":   CCounter,
+	"date":      CDate,
+	"decimal":   CDecimal,
+	"double":    CDouble,
+	"duration":  CDuration,
+	"float":     CFloat,
+	"inet":      CInet,
+	"int":       CInt,
+	"smallint":  CSmallInt,
+	"text":      CText,
+	"time":      CTime,
+	"timestamp": CTimestamp,
+	"timeuuid":  CTimeuuid,
+	"tinyint":   CTinyInt,
+	"uuid":      CUuid,
+	"varchar":   CVarchar,
+	"varint":    CVarint,
+	// the representations of the incomplete types
+	"udt":    CUdt,
+	"custom": CCustom,
+}
+
+// Parses a CQL type, e.g. "map<text, frozen<list<tuple<int, text>>>>",
+// and returns the corresponding CassType. Besides the CQL syntax, this
+// accepts everything returned by CassType.String(), so UDTs can be
+// given with their fields, e.g. "ks.address(street text, zip int)".
+// Custom types are given as the quoted name of their Java class.
+func ParseCassType(s string) (CassType, error) {
+	p := &typeParser{input: s}
+	ct, err := p.parseType()
+	if err == nil {
+		p.skipSpaces()
+		if p.pos < len(p.input) {
+			err = p.errorf("unexpected %q", p.input[p.pos:])
+		}
+	}
+	if err != nil {
+		return CUnknown, err
+	}
+	return ct, nil
+}
+
+type typeParser struct {
+	input string
+	pos   int
+}
+
+func (p *typeParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid type %q at position %d: %s", p.input, p.pos,
+		fmt.Sprintf(format, args...))
+}
+
+func (p *typeParser) skipSpaces() {
+	for p.pos < len(p.input) && strings.IndexByte(" \t\r\n", p.input[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// consumes the given character if it's next
+func (p *typeParser) accept(c byte) bool {
+	p.skipSpaces()
+	if p.pos < len(p.input) && p.input[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *typeParser) expect(c byte) error {
+	if !p.accept(c) {
+		if p.pos >= len(p.input) {
+			return p.errorf("expected %q", c)
+		}
+		return p.errorf("expected %q, got %q", c, p.input[p.pos])
+	}
+	return nil
+}
+
+// returns an identifier and whether it was quoted
+func (p *typeParser) identifier() (string, bool, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.input) {
+		return "", false, p.errorf("expected a type")
+	}
+	if p.input[p.pos] == '"' {
+		s, err := p.quoted('"')
+		return s, true, err
+	}
+	start := p.pos
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		if !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && !isDigit(c) && c != '_' {
+			break
+		}
+		p.pos++
+	}
+	if start == p.pos {
+		return "", false, p.errorf("unexpected %q", p.input[p.pos])
+	}
+	return strings.ToLower(p.input[start:p.pos]), false, nil
+}
+
+// parses a string delimited by quote in which the quote is escaped
+// by doubling it
+func (p *typeParser) quoted(quote byte) (string, error) {
+	p.pos++
+	var buf []byte
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		p.pos++
+		if c != quote {
+			buf = append(buf, c)
+			continue
+		}
+		if p.pos < len(p.input) && p.input[p.pos] == quote {
+			buf = append(buf, quote)
+			p.pos++
+			continue
+		}
+		return string(buf), nil
+	}
+	return "", p.errorf("unterminated %c", quote)
+}
+
+func (p *typeParser) parseType() (CassType, error) {
+	p.skipSpaces()
+	if p.pos < len(p.input) && p.input[p.pos] == '\'' {
+		class, err := p.quoted('\'')
+		if err != nil {
+			return CUnknown, err
+		}
+		ct := CCustom
+		ct.class = class
+		return ct, nil
+	}
+
+	name, quoted, err := p.identifier()
+	if err != nil {
+		return CUnknown, err
+	}
+	if !quoted {
+		switch name {
+		case "frozen":
+			return p.parseFrozen()
+		case "list", "set", "map", "tuple":
+			return p.parseParameterized(name)
+		}
+		if ct, ok := nativeTypes[name]; ok {
+			return ct, nil
+		}
+	}
+	return p.parseUDT(name)
+}
+
+func (p *typeParser) parseFrozen() (CassType, error) {
+	if err := p.expect('<'); err != nil {
+		return CUnknown, err
+	}
+	ct, err := p.parseType()
+	if err != nil {
+		return CUnknown, err
+	}
+	switch ct.primary {
+	case CASS_VALUE_TYPE_LIST, CASS_VALUE_TYPE_SET, CASS_VALUE_TYPE_MAP,
+		CASS_VALUE_TYPE_TUPLE, CASS_VALUE_TYPE_UDT:
+	default:
+		return CUnknown, p.errorf("%s cannot be frozen", ct.String())
+	}
+	if err := p.expect('>'); err != nil {
+		return CUnknown, err
+	}
+	return ct.Frozen(), nil
+}
+
+func (p *typeParser) parseParameterized(name string) (CassType, error) {
+	var ct CassType
+	var arity int
+	switch name {
+	case "list":
+		ct, arity = CList, 1
+	case "set":
+		ct, arity = CSet, 1
+	case "map":
+		ct, arity = CMap, 2
+	case "tuple":
+		ct, arity = CTuple, -1
+	}
+
+	if err := p.expect('<'); err != nil {
+		return CUnknown, err
+	}
+	var subtypes []CassType
+	for {
+		st, err := p.parseType()
+		if err != nil {
+			return CUnknown, err
+		}
+		subtypes = append(subtypes, st)
+		if !p.accept(',') {
+			break
+		}
+	}
+	if err := p.expect('>'); err != nil {
+		return CUnknown, err
+	}
+	if arity > 0 && len(subtypes) != arity {
+		return CUnknown, p.errorf("%s expects %d types, got %d", name, arity, len(subtypes))
+	}
+	return ct.Specialize(subtypes...), nil
+}
+
+// parses [keyspace.]name[(field type, ...)] once the first identifier
+// was read
+func (p *typeParser) parseUDT(name string) (CassType, error) {
+	ct := CUdt
+	ct.name = name
+	if p.accept('.') {
+		typeName, _, err := p.identifier()
+		if err != nil {
+			return CUnknown, err
+		}
+		ct.keyspace, ct.name = name, typeName
+	}
+	if !p.accept('(') {
+		return ct, nil
+	}
+	for {
+		field, _, err := p.identifier()
+		if err != nil {
+			return CUnknown, err
+		}
+		st, err := p.parseType()
+		if err != nil {
+			return CUnknown, err
+		}
+		ct.fieldNames = append(ct.fieldNames, field)
+		ct.subtypes = append(ct.subtypes, st)
+		if !p.accept(',') {
+			break
+		}
+	}
+	if err := p.expect(')'); err != nil {
+		return CUnknown, err
+	}
+	return ct, nil
+}
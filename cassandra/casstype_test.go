@@ -14,3 +14,101 @@ func TestCassTypeName(t *testing.T) {
 		t.Errorf("%s != %s", tupleType.String(), expected)
 	}
 }
+
+func TestFrozenCassTypeName(t *testing.T) {
+	mapType := cassandra.CMap.Specialize(cassandra.CText,
+		cassandra.CList.Specialize(cassandra.CInt).Frozen())
+	var expected = "map<text, frozen<list<int>>>"
+	if expected != mapType.String() {
+		t.Errorf("%s != %s", mapType.String(), expected)
+	}
+	if !mapType.Frozen().IsFrozen() || mapType.IsFrozen() {
+		t.Errorf("only the frozen copy of %s should be frozen", mapType.String())
+	}
+	if mapType.Equals(mapType.Frozen()) {
+		t.Errorf("%s should differ from its frozen version", mapType.String())
+	}
+}
+
+func TestParseCassType(t *testing.T) {
+	cases := map[string]cassandra.CassType{
+		"int":           cassandra.CInt,
+		" TEXT ":        cassandra.CText,
+		"list<bigint>":  cassandra.CList.Specialize(cassandra.CBigInt),
+		"set<duration>": cassandra.CSet.Specialize(cassandra.CDuration),
+		"map<text, frozen<list<tuple<int, text>>>>": cassandra.CMap.Specialize(cassandra.CText,
+			cassandra.CList.Specialize(cassandra.CTuple.Specialize(cassandra.CInt, cassandra.CText)).Frozen()),
+		"tuple<int,list<timeuuid>>": cassandra.CTuple.Specialize(cassandra.CInt,
+			cassandra.CList.Specialize(cassandra.CTimeuuid)),
+	}
+	for str, expected := range cases {
+		ct, err := cassandra.ParseCassType(str)
+		if err != nil {
+			t.Errorf("%s: %s", str, err)
+			continue
+		}
+		if !ct.Equals(expected) {
+			t.Errorf("%s: expected %s, got %s", str, expected.String(), ct.String())
+		}
+	}
+}
+
+func TestParseUDTAndCustomTypes(t *testing.T) {
+	udt, err := cassandra.ParseCassType(`frozen<Ks."Address"(street text, "Zip" int, tags set<text>)>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !udt.IsFrozen() || udt.Keyspace() != "ks" || udt.Name() != "Address" {
+		t.Errorf("unexpected UDT %s", udt.String())
+	}
+	if names := udt.FieldNames(); len(names) != 3 || names[1] != "Zip" {
+		t.Errorf("unexpected fields %v", names)
+	}
+	if sub := udt.Subtypes(); len(sub) != 3 || !sub[2].Equals(cassandra.CSet.Specialize(cassandra.CText)) {
+		t.Errorf("unexpected field types in %s", udt.String())
+	}
+
+	custom, err := cassandra.ParseCassType("'org.apache.cassandra.db.marshal.DynamicCompositeType'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if custom.Class() != "org.apache.cassandra.db.marshal.DynamicCompositeType" {
+		t.Errorf("unexpected class %s", custom.Class())
+	}
+}
+
+func TestCassTypeStringRoundTrip(t *testing.T) {
+	types := []string{
+		"map<text, frozen<list<frozen<tuple<int, text>>>>>",
+		"list<frozen<set<timeuuid>>>",
+		`frozen<ks."Address"(street text, "Zip" int, tags set<text>)>`,
+		"list<frozen<address>>",
+		`"list"`,
+		"'org.apache.cassandra.db.marshal.DateType'",
+		"udt",
+		"custom",
+	}
+	for _, str := range types {
+		ct, err := cassandra.ParseCassType(str)
+		if err != nil {
+			t.Errorf("%s: %s", str, err)
+			continue
+		}
+		if ct.String() != str {
+			t.Errorf("%s != %s", ct.String(), str)
+		}
+		again, err := cassandra.ParseCassType(ct.String())
+		if err != nil || !again.Equals(ct) {
+			t.Errorf("%s does not round-trip (%v)", str, err)
+		}
+	}
+}
+
+func TestParseInvalidCassType(t *testing.T) {
+	for _, str := range []string{"", "list", "list<>", "list<int", "map<int>", "frozen<int>",
+		"set<int, int>", "tuple<int>>", "'unterminated", "ks.", "addr(street)"} {
+		if ct, err := cassandra.ParseCassType(str); err == nil {
+			t.Errorf("%q should be invalid (got %s)", str, ct.String())
+		}
+	}
+}
@@ -16,6 +16,8 @@ type Cluster struct {
 }
 
 func NewCluster(contactPoints ...string) *Cluster {
+	installLogCallback()
+
 	cluster := new(Cluster)
 	cluster.cptr = C.cass_cluster_new()
 	cContactPoints := C.CString(strings.Join(contactPoints, ","))
@@ -25,6 +27,15 @@ func NewCluster(contactPoints ...string) *Cluster {
 	return cluster
 }
 
+// Sets the port used to connect to the contact points (and the other
+// nodes of the cluster). The default is 9042.
+func (cluster *Cluster) SetPort(port int) error {
+	if retc := C.cass_cluster_set_port(cluster.cptr, C.int(port)); retc != C.CASS_OK {
+		return newError(retc)
+	}
+	return nil
+}
+
 func (cluster *Cluster) SetProtocolVersion(version uint8) {
 	if version < 1 {
 		panic("protocol version must be > 1")
@@ -181,6 +192,28 @@ func (cluster *Cluster) Connect() (*Session, error) {
 	return session, nil
 }
 
+// Connects to the cluster and sets the keyspace used by
+// the session's statements.
+func (cluster *Cluster) ConnectKeyspace(keyspace string) (*Session, error) {
+	session := new(Session)
+	session.cptr = C.cass_session_new()
+	session.Cluster = cluster
+	session.keyspace = keyspace
+
+	cKeyspace := C.CString(keyspace)
+	defer C.free(unsafe.Pointer(cKeyspace))
+
+	future := async(func() *C.struct_CassFuture_ {
+		return C.cass_session_connect_keyspace(session.cptr, cluster.cptr, cKeyspace)
+	})
+	defer future.Close()
+
+	if err := future.Error(); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
 type connectionOptions struct {
 	ConnectionTimeout               uint
 	HeartbeatInterval               uint
@@ -0,0 +1,85 @@
+package cassandra
+
+import (
+	"reflect"
+	"sync"
+)
+
+// A Codec maps a Go type the driver doesn't know about to a Cassandra
+// type. It converts the values of the Go type to and from native
+// values, i.e. values of the Go types the driver supports for the
+// Cassandra type (e.g. a string for text, a *Decimal for decimal).
+type Codec interface {
+	// The Cassandra type the values are written as when the statement
+	// doesn't provide the type of the bound values (simple statements).
+	CassType() CassType
+	// Converts a value of the codec's Go type to a native value, or
+	// to nil for null.
+	Encode(value interface{}) (interface{}, error)
+	// Converts a native value into dst, which is a pointer to the
+	// codec's Go type. The native values are of the type values of
+	// the Cassandra type are read into when scanning an interface{}
+	// (e.g. string for text, int64 for bigint, []T for list<T>).
+	// native is nil for null values.
+	Decode(native interface{}, dst interface{}) error
+}
+
+var codecs = struct {
+	sync.RWMutex
+	byType map[reflect.Type]Codec
+}{byType: make(map[reflect.Type]Codec)}
+
+// Registers the codec used to bind and scan values of the given Go
+// type, e.g. RegisterCodec(reflect.TypeOf(netip.Addr{}), addrCodec{}).
+// Codecs are consulted before the built-in conversions, for values of
+// the type and pointers to it. Registering a nil codec removes the
+// codec of the type.
+func RegisterCodec(goType reflect.Type, codec Codec) {
+	codecs.Lock()
+	defer codecs.Unlock()
+	if codec == nil {
+		delete(codecs.byType, goType)
+		return
+	}
+	codecs.byType[goType] = codec
+}
+
+func lookupCodec(goType reflect.Type) (Codec, bool) {
+	codecs.RLock()
+	defer codecs.RUnlock()
+	if len(codecs.byType) == 0 {
+		return nil, false
+	}
+	codec, ok := codecs.byType[goType]
+	return codec, ok
+}
+
+// Returns the codec for a value being bound, if any.
+func codecForValue(value interface{}) (Codec, interface{}, bool) {
+	if value == nil {
+		return nil, nil, false
+	}
+	t := reflect.TypeOf(value)
+	if codec, ok := lookupCodec(t); ok {
+		return codec, value, true
+	}
+	if t.Kind() == reflect.Ptr {
+		if codec, ok := lookupCodec(t.Elem()); ok {
+			rVal := reflect.ValueOf(value)
+			if rVal.IsNil() {
+				return codec, nil, true
+			}
+			return codec, rVal.Elem().Interface(), true
+		}
+	}
+	return nil, nil, false
+}
+
+// Returns the codec for a pointer a value is read into, if any.
+func codecForDst(dst interface{}) (Codec, bool) {
+	t := reflect.TypeOf(dst)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return nil, false
+	}
+	return lookupCodec(t.Elem())
+}
@@ -0,0 +1,134 @@
+package cassandra_test
+
+import (
+	"fmt"
+	"golang-driver/cassandra"
+	"golang-driver/cassandra/test"
+	"net"
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+// amounts of money stored as bigint cents
+type Money int64
+
+type moneyCodec struct{}
+
+func (moneyCodec) CassType() cassandra.CassType {
+	return cassandra.CBigInt
+}
+
+func (moneyCodec) Encode(value interface{}) (interface{}, error) {
+	return int64(value.(Money)), nil
+}
+
+func (moneyCodec) Decode(native interface{}, dst interface{}) error {
+	if native == nil {
+		*dst.(*Money) = 0
+		return nil
+	}
+	*dst.(*Money) = Money(native.(int64))
+	return nil
+}
+
+type addrCodec struct{}
+
+func (addrCodec) CassType() cassandra.CassType {
+	return cassandra.CInet
+}
+
+func (addrCodec) Encode(value interface{}) (interface{}, error) {
+	addr := value.(netip.Addr)
+	if !addr.IsValid() {
+		return nil, nil
+	}
+	return net.IP(addr.AsSlice()), nil
+}
+
+func (addrCodec) Decode(native interface{}, dst interface{}) error {
+	if native == nil {
+		*dst.(*netip.Addr) = netip.Addr{}
+		return nil
+	}
+	addr, ok := netip.AddrFromSlice(native.(net.IP))
+	if !ok {
+		return fmt.Errorf("invalid address %v", native)
+	}
+	*dst.(*netip.Addr) = addr.Unmap()
+	return nil
+}
+
+func TestCodecs(t *testing.T) {
+	cassandra.RegisterCodec(reflect.TypeOf(Money(0)), moneyCodec{})
+	defer cassandra.RegisterCodec(reflect.TypeOf(Money(0)), nil)
+	cassandra.RegisterCodec(reflect.TypeOf(netip.Addr{}), addrCodec{})
+	defer cassandra.RegisterCodec(reflect.TypeOf(netip.Addr{}), nil)
+
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	if err := test.Setup(codecsSetup); err != nil {
+		t.Log("Unexpected error while setup. You might need to clean up manually golang_driver keyspace")
+		t.Fatal(err)
+	}
+	defer test.TearDown(codecsCleanup)
+
+	pstmt, err := session.Prepare("INSERT INTO golang_driver.codecs (id, amount, addr, addrs) VALUES (?, ?, ?, ?)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pstmt.Close()
+
+	addrs := []netip.Addr{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("::1")}
+	if _, err := pstmt.Exec(int32(1), Money(1234), addrs[0], addrs); err != nil {
+		t.Fatal(err)
+	}
+	// simple statements use the type given by the codec
+	if _, err := session.Exec("INSERT INTO golang_driver.codecs (id, amount, addr) VALUES (?, ?, ?)",
+		int32(2), Money(-5), (*netip.Addr)(nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		id     int32
+		amount Money
+		addr   netip.Addr
+		addrs  []netip.Addr
+	}{
+		{1, 1234, addrs[0], addrs},
+		{2, -5, netip.Addr{}, nil},
+	}
+	for _, c := range cases {
+		rows, err := session.Exec("SELECT amount, addr, addrs FROM golang_driver.codecs WHERE id = ?", c.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !rows.Next() {
+			rows.Close()
+			t.Fatalf("expected row %d", c.id)
+		}
+		var amount Money
+		var addr netip.Addr
+		var raddrs []netip.Addr
+		if err := rows.Scan(&amount, &addr, &raddrs); err != nil {
+			t.Fatal(err)
+		}
+		rows.Close()
+		if amount != c.amount || addr != c.addr || !reflect.DeepEqual(raddrs, c.addrs) {
+			t.Errorf("row %d: expected (%d, %v, %v), got (%d, %v, %v)", c.id,
+				c.amount, c.addr, c.addrs, amount, addr, raddrs)
+		}
+	}
+}
+
+var (
+	codecsSetup = []string{
+		"CREATE KEYSPACE IF NOT EXISTS golang_driver WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};",
+		`CREATE TABLE IF NOT EXISTS golang_driver.codecs(id int PRIMARY KEY, amount bigint, addr inet, addrs list<inet>)`,
+	}
+
+	codecsCleanup = []string{
+		"DROP TABLE golang_driver.codecs",
+	}
+)
@@ -0,0 +1,159 @@
+package cassandra_test
+
+import (
+	"golang-driver/cassandra"
+	"golang-driver/cassandra/test"
+	"testing"
+)
+
+func TestCounters(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	if err := test.Setup(countersSetup); err != nil {
+		t.Log("Unexpected error while setup. You might need to clean up manuallly golang_driver keyspace")
+		t.Fatal(err)
+	}
+	defer test.TearDown(countersCleanup)
+
+	testUpdateCounterUsingPreparedStatement(t, session)
+	testCounterUpdate(t, session)
+	testLoggedBatch(t, session)
+}
+
+func testUpdateCounterUsingPreparedStatement(t *testing.T, session *cassandra.Session) {
+	pstmt, err := session.Prepare("UPDATE golang_driver.counters SET hits = hits + ? WHERE id = ?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pstmt.Close()
+
+	if _, err := pstmt.Exec(int64(5), int32(1)); err != nil {
+		t.Fatal(err)
+	}
+	// any integer can be bound to a counter
+	if _, err := pstmt.Exec(-2, int32(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	if hits := readCounter(t, session, "hits", 1); hits != 3 {
+		t.Errorf("expected hits to be 3, got %d", hits)
+	}
+}
+
+func testCounterUpdate(t *testing.T, session *cassandra.Session) {
+	update := session.CounterUpdate("golang_driver.counters", "id").
+		Increment("hits", 10, int32(2)).
+		Increment("misses", 4, int32(2)).
+		Decrement("hits", 3, int32(2))
+	if update.Size() != 3 {
+		t.Errorf("expected 3 pending updates, got %d", update.Size())
+	}
+	if err := update.Exec(); err != nil {
+		t.Fatal(err)
+	}
+	if update.Size() != 0 {
+		t.Errorf("expected no pending updates after Exec, got %d", update.Size())
+	}
+
+	if hits := readCounter(t, session, "hits", 2); hits != 7 {
+		t.Errorf("expected hits to be 7, got %d", hits)
+	}
+	if misses := readCounter(t, session, "misses", 2); misses != 4 {
+		t.Errorf("expected misses to be 4, got %d", misses)
+	}
+
+	if err := session.CounterUpdate("golang_driver.counters", "id").
+		Increment("hits", 1).Exec(); err == nil {
+		t.Error("expected an error for missing key values")
+	}
+
+	// mixed case and reserved names are quoted
+	if err := session.CounterUpdate("golang_driver.MixedCounters", "Id").
+		Increment("Hits", 5, int32(1)).
+		Increment("order", 2, int32(1)).
+		Exec(); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := session.Exec(`SELECT "Hits", "order" FROM golang_driver."MixedCounters" WHERE "Id" = ?`, int32(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var hits, order int64
+	if !rows.Next() {
+		t.Fatal("no counters for id 1")
+	}
+	if err := rows.Scan(&hits, &order); err != nil {
+		t.Fatal(err)
+	}
+	if hits != 5 || order != 2 {
+		t.Errorf("expected 5 hits and 2 orders, got %d and %d", hits, order)
+	}
+}
+
+func testLoggedBatch(t *testing.T, session *cassandra.Session) {
+	batch := session.NewBatch(cassandra.LoggedBatch).
+		Query("INSERT INTO golang_driver.batched (id, name) VALUES (?, ?)", int32(1), "one").
+		Query("INSERT INTO golang_driver.batched (id, name) VALUES (?, ?)", int32(2), "two").
+		WithConsistency(cassandra.ONE)
+	defer batch.Close()
+
+	if batch.Size() != 2 {
+		t.Errorf("expected 2 statements, got %d", batch.Size())
+	}
+	rows, err := batch.Exec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows.Close()
+
+	rows, err = session.Exec("SELECT count(*) FROM golang_driver.batched")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var count int64
+	if !rows.Next() {
+		t.Fatal("expected a row")
+	}
+	if err := rows.Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 rows, got %d", count)
+	}
+}
+
+func readCounter(t *testing.T, session *cassandra.Session, column string, id int32) int64 {
+	rows, err := session.Exec("SELECT "+column+" FROM golang_driver.counters WHERE id = ?", id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		t.Fatalf("no counters for id %d", id)
+	}
+	var v int64
+	if err := rows.Scan(&v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+var (
+	countersSetup = []string{
+		"CREATE KEYSPACE IF NOT EXISTS golang_driver WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};",
+		`CREATE TABLE IF NOT EXISTS golang_driver.counters(id int PRIMARY KEY, hits counter, misses counter)`,
+		`CREATE TABLE IF NOT EXISTS golang_driver.batched(id int PRIMARY KEY, name text)`,
+		`CREATE TABLE IF NOT EXISTS golang_driver."MixedCounters"("Id" int PRIMARY KEY, "Hits" counter, "order" counter)`,
+	}
+
+	countersCleanup = []string{
+		"DROP TABLE golang_driver.counters",
+		"DROP TABLE golang_driver.batched",
+		`DROP TABLE golang_driver."MixedCounters"`,
+	}
+)
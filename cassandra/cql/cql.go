@@ -0,0 +1,34 @@
+// Package cql formats identifiers and values as CQL, e.g. to generate
+// scripts or to log statements with their values (see
+// cassandra.Statement.Interpolate):
+//
+//	cql.QuoteIdentifier("userId")                           // "userId"
+//	cql.Literal("it's", cassandra.CText)                    // 'it''s'
+//	cql.Literal([]byte{0xca, 0xfe}, cassandra.CBlob)        // 0xcafe
+//	cql.Literal(map[string]int{"a": 1}, cassandra.CUnknown) // {'a': 1}
+package cql
+
+import (
+	"golang-driver/cassandra"
+	"golang-driver/cassandra/internal/cqltext"
+)
+
+// Returns the identifier as it must be written in CQL: unchanged if
+// it's lower case and not a reserved keyword, or double quoted (e.g.
+// "userId" or "order") otherwise.
+func QuoteIdentifier(name string) string {
+	return cqltext.QuoteIdentifier(name)
+}
+
+// Returns the name of a table, a type or a function, which may be
+// qualified by its keyspace (e.g. shop.orders), as it must be written
+// in CQL.
+func QuoteName(name string) string {
+	return cqltext.QuoteName(name)
+}
+
+// Returns the value as a CQL literal of the type (see
+// cassandra.CassType.Literal).
+func Literal(value interface{}, dataType cassandra.CassType) (string, error) {
+	return dataType.Literal(value)
+}
@@ -0,0 +1,33 @@
+package cassandra_test
+
+import (
+	"golang-driver/cassandra/test"
+	"testing"
+)
+
+func TestCustomPayload(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	stmt, err := session.Query("select keyspace_name from system.schema_keyspaces")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+
+	payload := map[string][]byte{
+		"audit.user":  []byte("golang_driver"),
+		"audit.empty": []byte{},
+	}
+	rows, err := stmt.WithCustomPayload(payload).Exec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	// a server without a query handler doesn't echo back the payload
+	if p := rows.CustomPayload(); len(p) != 0 {
+		t.Errorf("unexpected custom payload in response: %v", p)
+	}
+	test.IterateRows(rows, t)
+}
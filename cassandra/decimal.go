@@ -0,0 +1,219 @@
+package cassandra
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// Determines how the digits dropped by Rescale and Quo are rounded.
+type RoundingMode int
+
+const (
+	// rounds towards the nearest neighbor, away from zero when
+	// both neighbors are equidistant
+	RoundHalfUp RoundingMode = iota
+	// rounds towards the nearest neighbor, towards the even one when
+	// both neighbors are equidistant (banker's rounding)
+	RoundHalfEven
+	// rounds towards the nearest neighbor, towards zero when both
+	// neighbors are equidistant
+	RoundHalfDown
+	// rounds away from zero
+	RoundUp
+	// rounds towards zero (truncates)
+	RoundDown
+	// rounds towards positive infinity
+	RoundCeiling
+	// rounds towards negative infinity
+	RoundFloor
+)
+
+var bigTen = big.NewInt(10)
+
+// returns 10^n
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(n), nil)
+}
+
+// the zero Decimal (with a nil Value) is 0
+func (d *Decimal) unscaled() *big.Int {
+	if d.Value == nil {
+		return new(big.Int)
+	}
+	return d.Value
+}
+
+func checkScale(scale int64) int32 {
+	if scale < math.MinInt32 || scale > math.MaxInt32 {
+		panic(fmt.Sprintf("decimal scale %d out of range", scale))
+	}
+	return int32(scale)
+}
+
+// returns the unscaled values of d and other for the greater of their
+// scales, and that scale
+func align(d, other *Decimal) (*big.Int, *big.Int, int32) {
+	x, y := d.unscaled(), other.unscaled()
+	switch {
+	case d.Scale < other.Scale:
+		x = new(big.Int).Mul(x, pow10(int64(other.Scale)-int64(d.Scale)))
+		return x, y, other.Scale
+	case d.Scale > other.Scale:
+		y = new(big.Int).Mul(y, pow10(int64(d.Scale)-int64(other.Scale)))
+	}
+	return x, y, d.Scale
+}
+
+// Compares d and other and returns -1, 0 or +1. Decimals with the
+// same value and different scales (e.g. 1.0 and 1.00) are equal.
+func (d *Decimal) Cmp(other *Decimal) int {
+	x, y, _ := align(d, other)
+	return x.Cmp(y)
+}
+
+// Returns -1, 0 or +1 depending on the sign of d.
+func (d *Decimal) Sign() int {
+	return d.unscaled().Sign()
+}
+
+// Returns d+other, with the greater of their scales.
+func (d *Decimal) Add(other *Decimal) *Decimal {
+	x, y, scale := align(d, other)
+	return &Decimal{new(big.Int).Add(x, y), scale}
+}
+
+// Returns d-other, with the greater of their scales.
+func (d *Decimal) Sub(other *Decimal) *Decimal {
+	x, y, scale := align(d, other)
+	return &Decimal{new(big.Int).Sub(x, y), scale}
+}
+
+// Returns d*other, with the sum of their scales. It panics if the
+// scale overflows.
+func (d *Decimal) Mul(other *Decimal) *Decimal {
+	value := new(big.Int).Mul(d.unscaled(), other.unscaled())
+	return &Decimal{value, checkScale(int64(d.Scale) + int64(other.Scale))}
+}
+
+// Returns d/other with the given scale, rounded with the given mode.
+// Returns an error if other is zero.
+func (d *Decimal) Quo(other *Decimal, scale int32, mode RoundingMode) (*Decimal, error) {
+	if other.Sign() == 0 {
+		return nil, fmt.Errorf("division of %s by zero", d.String())
+	}
+	return NewDecimalFromRat(new(big.Rat).Quo(d.Rat(), other.Rat()), scale, mode), nil
+}
+
+// Returns d with the given scale, rounded with the given mode if
+// the scale is smaller than the scale of d.
+func (d *Decimal) Rescale(scale int32, mode RoundingMode) *Decimal {
+	diff := int64(scale) - int64(d.Scale)
+	if diff >= 0 {
+		return &Decimal{new(big.Int).Mul(d.unscaled(), pow10(diff)), scale}
+	}
+	return &Decimal{roundQuo(d.unscaled(), pow10(-diff), mode), scale}
+}
+
+// Returns d without the trailing zeros of its fractional part
+// (e.g. 1.2300 becomes 1.23 and 5.00 becomes 5).
+func (d *Decimal) Normalize() *Decimal {
+	value, scale := new(big.Int).Set(d.unscaled()), d.Scale
+	if value.Sign() == 0 {
+		return &Decimal{value, 0}
+	}
+	q, r := new(big.Int), new(big.Int)
+	for scale > 0 {
+		q.QuoRem(value, bigTen, r)
+		if r.Sign() != 0 {
+			break
+		}
+		value.Set(q)
+		scale--
+	}
+	return &Decimal{value, scale}
+}
+
+// Returns the float64 nearest to d, and whether it's exactly equal
+// to d.
+func (d *Decimal) Float64() (float64, bool) {
+	return d.Rat().Float64()
+}
+
+// Returns d as a rational number.
+func (d *Decimal) Rat() *big.Rat {
+	r := new(big.Rat).SetInt(d.unscaled())
+	if d.Scale > 0 {
+		return r.Quo(r, new(big.Rat).SetInt(pow10(int64(d.Scale))))
+	}
+	return r.Mul(r, new(big.Rat).SetInt(pow10(-int64(d.Scale))))
+}
+
+// Creates a Decimal holding r with the given scale, rounded with the
+// given mode.
+func NewDecimalFromRat(r *big.Rat, scale int32, mode RoundingMode) *Decimal {
+	num := new(big.Int).Set(r.Num())
+	den := new(big.Int).Set(r.Denom())
+	if scale >= 0 {
+		num.Mul(num, pow10(int64(scale)))
+	} else {
+		den.Mul(den, pow10(-int64(scale)))
+	}
+	return &Decimal{roundQuo(num, den, mode), scale}
+}
+
+// Creates a Decimal holding exactly the value of f, with the
+// smallest scale (at least 0) needed. Returns an error if f is an
+// infinity.
+func NewDecimalFromBigFloat(f *big.Float) (*Decimal, error) {
+	if f.IsInf() {
+		return nil, fmt.Errorf("%s cannot be converted to a decimal", f.String())
+	}
+	r, _ := f.Rat(nil)
+	// the denominator is a power of 2, 2^n, so the value has n
+	// decimal digits at most: num/2^n = num*5^n/10^n
+	n := int64(r.Denom().BitLen() - 1)
+	value := new(big.Int).Mul(r.Num(), new(big.Int).Exp(big.NewInt(5), big.NewInt(n), nil))
+	return (&Decimal{value, checkScale(n)}).Normalize(), nil
+}
+
+// returns num/den rounded with the given mode (den must be positive)
+func roundQuo(num, den *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	// the sign of the exact quotient (q is truncated towards zero)
+	sign := num.Sign()
+	var away bool
+	switch mode {
+	case RoundUp:
+		away = true
+	case RoundDown:
+		away = false
+	case RoundCeiling:
+		away = sign > 0
+	case RoundFloor:
+		away = sign < 0
+	default:
+		// compare the remainder to half of the divisor
+		half := new(big.Int).Abs(r)
+		half.Lsh(half, 1)
+		switch c := half.Cmp(den); {
+		case c > 0:
+			away = true
+		case c < 0:
+			away = false
+		case mode == RoundHalfUp:
+			away = true
+		case mode == RoundHalfDown:
+			away = false
+		default:
+			away = q.Bit(0) == 1
+		}
+	}
+	if away {
+		q.Add(q, big.NewInt(int64(sign)))
+	}
+	return q
+}
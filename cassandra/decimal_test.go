@@ -60,6 +60,158 @@ func TestParseDecimal(t *testing.T) {
 	// t.Error("log")
 }
 
+func TestDecimalString(t *testing.T) {
+	cases := map[string]*cassandra.Decimal{
+		"0.05":     cassandra.NewDecimal(5, 2),
+		"-0.05":    cassandra.NewDecimal(-5, 2),
+		"-0.5":     cassandra.NewDecimal(-5, 1),
+		"0.123":    cassandra.NewDecimal(123, 3),
+		"42":       cassandra.NewDecimal(42, 0),
+		"1.2E+3":   cassandra.NewDecimal(12, -2),
+		"-5E+1":    cassandra.NewDecimal(-5, -1),
+		"0":        {},
+		"0.000":    cassandra.NewDecimal(0, 3),
+		"-123.456": cassandra.NewDecimal(-123456, 3),
+	}
+	for expected, d := range cases {
+		if s := d.String(); s != expected {
+			t.Errorf("expected %s, got %s", expected, s)
+		}
+		parsed, err := cassandra.ParseDecimal(d.String())
+		if err != nil || parsed.Cmp(d) != 0 || parsed.Scale != d.Scale {
+			t.Errorf("%s does not round-trip: %v (%v)", expected, parsed, err)
+		}
+	}
+}
+
+func TestParseDecimalExponent(t *testing.T) {
+	cases := []struct {
+		str   string
+		value int64
+		scale int32
+	}{
+		{"1.5e3", 15, -2},
+		{"-2.25E-2", -225, 4},
+		{"7E0", 7, 0},
+		{"+.5", 5, 1},
+		{"12e+1", 12, -1},
+	}
+	for _, c := range cases {
+		d, err := cassandra.ParseDecimal(c.str)
+		if err != nil {
+			t.Errorf("%s: %s", c.str, err)
+			continue
+		}
+		if d.Value.Int64() != c.value || d.Scale != c.scale {
+			t.Errorf("%s: expected %d scale %d, got %d scale %d", c.str, c.value, c.scale, d.Value, d.Scale)
+		}
+	}
+	for _, str := range []string{"e5", "1e", "1e5.5", "--1", "1.2.3e4", "1e99999999999"} {
+		if d, err := cassandra.ParseDecimal(str); err == nil {
+			t.Errorf("%q should not be a valid decimal (got %s)", str, d)
+		}
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	price := cassandra.NewDecimal(1999, 2)
+	qty := cassandra.NewDecimal(3, 0)
+	rate := cassandra.NewDecimal(825, 4)
+
+	if s := price.Add(cassandra.NewDecimal(1, 3)).String(); s != "19.991" {
+		t.Errorf("expected 19.991, got %s", s)
+	}
+	if s := price.Sub(cassandra.NewDecimal(2, 0)).String(); s != "17.99" {
+		t.Errorf("expected 17.99, got %s", s)
+	}
+	total := price.Mul(qty)
+	if s := total.String(); s != "59.97" {
+		t.Errorf("expected 59.97, got %s", s)
+	}
+	if s := total.Mul(rate).Rescale(2, cassandra.RoundHalfUp).String(); s != "4.95" {
+		t.Errorf("expected 4.95, got %s", s)
+	}
+	if price.String() != "19.99" {
+		t.Errorf("the operands should not be modified, got %s", price)
+	}
+
+	if cassandra.NewDecimal(10, 1).Cmp(cassandra.NewDecimal(100, 2)) != 0 {
+		t.Error("1.0 and 1.00 should be equal")
+	}
+	if cassandra.NewDecimal(-1, 0).Cmp(cassandra.NewDecimal(1, 3)) != -1 {
+		t.Error("-1 should be less than 0.001")
+	}
+
+	q, err := cassandra.NewDecimal(10, 0).Quo(qty, 4, cassandra.RoundHalfEven)
+	if err != nil || q.String() != "3.3333" {
+		t.Errorf("expected 3.3333, got %v (%v)", q, err)
+	}
+	if _, err := price.Quo(&cassandra.Decimal{}, 2, cassandra.RoundHalfUp); err == nil {
+		t.Error("division by zero should fail")
+	}
+
+	if s := cassandra.NewDecimal(12300, 4).Normalize().String(); s != "1.23" {
+		t.Errorf("expected 1.23, got %s", s)
+	}
+	if s := cassandra.NewDecimal(500, 2).Normalize().String(); s != "5" {
+		t.Errorf("expected 5, got %s", s)
+	}
+}
+
+func TestDecimalRounding(t *testing.T) {
+	modes := []cassandra.RoundingMode{cassandra.RoundHalfUp, cassandra.RoundHalfEven,
+		cassandra.RoundHalfDown, cassandra.RoundUp, cassandra.RoundDown,
+		cassandra.RoundCeiling, cassandra.RoundFloor}
+	// the results for each mode, in the order above
+	cases := map[string][]string{
+		"5.5":  {"6", "6", "5", "6", "5", "6", "5"},
+		"2.5":  {"3", "2", "2", "3", "2", "3", "2"},
+		"1.6":  {"2", "2", "2", "2", "1", "2", "1"},
+		"1.1":  {"1", "1", "1", "2", "1", "2", "1"},
+		"-1.1": {"-1", "-1", "-1", "-2", "-1", "-1", "-2"},
+		"-2.5": {"-3", "-2", "-2", "-3", "-2", "-2", "-3"},
+		"-1.6": {"-2", "-2", "-2", "-2", "-1", "-1", "-2"},
+		"1.0":  {"1", "1", "1", "1", "1", "1", "1"},
+	}
+	for str, expected := range cases {
+		d, _ := cassandra.ParseDecimal(str)
+		for i, mode := range modes {
+			if s := d.Rescale(0, mode).String(); s != expected[i] {
+				t.Errorf("%s rounded with mode %d: expected %s, got %s", str, mode, expected[i], s)
+			}
+		}
+	}
+}
+
+func TestDecimalConversions(t *testing.T) {
+	d := cassandra.NewDecimal(-1225, 2)
+	if f, exact := d.Float64(); f != -12.25 || !exact {
+		t.Errorf("expected exactly -12.25, got %v (exact: %v)", f, exact)
+	}
+	if _, exact := cassandra.NewDecimal(1, 1).Float64(); exact {
+		t.Error("0.1 cannot be represented exactly as a float64")
+	}
+	if r := d.Rat(); r.Cmp(big.NewRat(-49, 4)) != 0 {
+		t.Errorf("expected -49/4, got %s", r)
+	}
+
+	fromRat := cassandra.NewDecimalFromRat(big.NewRat(2, 3), 3, cassandra.RoundHalfUp)
+	if fromRat.String() != "0.667" {
+		t.Errorf("expected 0.667, got %s", fromRat)
+	}
+	fromFloat, err := cassandra.NewDecimalFromBigFloat(big.NewFloat(0.375))
+	if err != nil || fromFloat.String() != "0.375" {
+		t.Errorf("expected 0.375, got %v (%v)", fromFloat, err)
+	}
+	fromFloat, err = cassandra.NewDecimalFromBigFloat(big.NewFloat(-1024))
+	if err != nil || fromFloat.String() != "-1024" {
+		t.Errorf("expected -1024, got %v (%v)", fromFloat, err)
+	}
+	if _, err := cassandra.NewDecimalFromBigFloat(new(big.Float).SetInf(false)); err == nil {
+		t.Error("infinity cannot be converted to a decimal")
+	}
+}
+
 func TestDecimalAndVarint(t *testing.T) {
 	session := test.GetSession()
 	defer test.Shutdown()
@@ -0,0 +1,370 @@
+package cassandra
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cassandra `duration` type (Cassandra 3.10+) represents a period of
+// time as a number of months, days and nanoseconds. The components
+// are kept apart because the length of a month or a day varies, so
+// a Duration can be converted to a time.Duration only when it has
+// no months and no days.
+//
+// All the non-zero components must have the same sign.
+type Duration struct {
+	Months      int32
+	Days        int32
+	Nanoseconds int64
+}
+
+// Creates a new Duration. Returns an error if the components
+// have different signs.
+func NewDuration(months, days int32, nanoseconds int64) (Duration, error) {
+	d := Duration{months, days, nanoseconds}
+	if err := d.validate(); err != nil {
+		return Duration{}, err
+	}
+	return d, nil
+}
+
+// Returns a Duration holding the given time.Duration as nanoseconds.
+func FromDuration(d time.Duration) Duration {
+	return Duration{Nanoseconds: int64(d)}
+}
+
+func (d Duration) validate() error {
+	if (d.Months >= 0 && d.Days >= 0 && d.Nanoseconds >= 0) ||
+		(d.Months <= 0 && d.Days <= 0 && d.Nanoseconds <= 0) {
+		return nil
+	}
+	return fmt.Errorf("duration components must all have the same sign (%d months, %d days, %d nanoseconds)",
+		d.Months, d.Days, d.Nanoseconds)
+}
+
+// Returns the Duration as a time.Duration. The second value is false
+// (and the returned time.Duration meaningless) if the Duration has
+// months or days, which don't have a fixed length.
+func (d Duration) Duration() (time.Duration, bool) {
+	if d.Months != 0 || d.Days != 0 {
+		return 0, false
+	}
+	return time.Duration(d.Nanoseconds), true
+}
+
+// Returns the time t+d. Months and days are added as calendar
+// months and days (see time.Time.AddDate).
+func (d Duration) AddTo(t time.Time) time.Time {
+	return t.AddDate(0, int(d.Months), int(d.Days)).Add(time.Duration(d.Nanoseconds))
+}
+
+func (d Duration) IsZero() bool {
+	return d.Months == 0 && d.Days == 0 && d.Nanoseconds == 0
+}
+
+var durationUnits = []struct {
+	unit  string
+	nanos int64
+}{
+	{"h", int64(time.Hour)},
+	{"m", int64(time.Minute)},
+	{"s", int64(time.Second)},
+	{"ms", int64(time.Millisecond)},
+	{"us", int64(time.Microsecond)},
+	{"ns", 1},
+}
+
+// Returns the duration in the CQL format (e.g. 1y2mo3d4h5m6s7ms8us9ns)
+func (d Duration) String() string {
+	if d.IsZero() {
+		return "0s"
+	}
+
+	var buf bytes.Buffer
+	months, days, nanos := int64(d.Months), int64(d.Days), d.Nanoseconds
+	if months < 0 || days < 0 || nanos < 0 {
+		buf.WriteByte('-')
+		months, days = -months, -days
+	}
+	if months/12 > 0 {
+		fmt.Fprintf(&buf, "%dy", months/12)
+	}
+	if months%12 > 0 {
+		fmt.Fprintf(&buf, "%dmo", months%12)
+	}
+	if days > 0 {
+		fmt.Fprintf(&buf, "%dd", days)
+	}
+	// math.MinInt64 nanoseconds cannot be negated
+	unanos := uint64(nanos)
+	if nanos < 0 {
+		unanos = uint64(-(nanos + 1)) + 1
+	}
+	for _, u := range durationUnits {
+		if n := unanos / uint64(u.nanos); n > 0 {
+			fmt.Fprintf(&buf, "%d%s", n, u.unit)
+			unanos %= uint64(u.nanos)
+		}
+	}
+	return buf.String()
+}
+
+// Returns a representation that can be used directly in CQL
+func (d Duration) NativeString() string {
+	return d.String()
+}
+
+// Parses a duration in one of the formats accepted by Cassandra.
+// The accepted formats are:
+// * 1y2mo3w4d5h6m7s8ms9us10ns (units in decreasing order, µs for us)
+// * P[nY][nM][nD][T[nH][nM][nS]] (ISO 8601)
+// * PnW (ISO 8601 weeks)
+// * P[YYYY]-[MM]-[DD]T[hh]:[mm]:[ss] (ISO 8601 alternative format)
+// All the formats accept a leading minus sign for negative durations.
+func ParseDuration(s string) (Duration, error) {
+	str := s
+	negative := strings.HasPrefix(str, "-")
+	if negative {
+		str = str[1:]
+	}
+	if str == "" {
+		return Duration{}, fmt.Errorf("invalid duration %q", s)
+	}
+
+	var b durationBuilder
+	var err error
+	switch {
+	case str[0] == 'P':
+		err = b.parseISO(str[1:])
+	default:
+		err = b.parseCQL(str)
+	}
+	if err != nil {
+		return Duration{}, fmt.Errorf("invalid duration %q: %s", s, err.Error())
+	}
+	return b.build(negative)
+}
+
+// accumulates the components of a duration being parsed, checking
+// for overflows
+type durationBuilder struct {
+	months, days, nanos uint64
+}
+
+const maxDurationNanos = uint64(math.MaxInt64) + 1
+
+func (b *durationBuilder) addMonths(n, factor uint64) error {
+	if n > math.MaxInt32/factor || b.months+n*factor > uint64(math.MaxInt32)+1 {
+		return fmt.Errorf("months overflow")
+	}
+	b.months += n * factor
+	return nil
+}
+
+func (b *durationBuilder) addDays(n, factor uint64) error {
+	if n > math.MaxInt32/factor || b.days+n*factor > uint64(math.MaxInt32)+1 {
+		return fmt.Errorf("days overflow")
+	}
+	b.days += n * factor
+	return nil
+}
+
+func (b *durationBuilder) addNanos(n, factor uint64) error {
+	if n > maxDurationNanos/factor || b.nanos+n*factor > maxDurationNanos {
+		return fmt.Errorf("nanoseconds overflow")
+	}
+	b.nanos += n * factor
+	return nil
+}
+
+func (b *durationBuilder) build(negative bool) (Duration, error) {
+	// the builder allows one extra unit for the negative range
+	if !negative && (b.months > math.MaxInt32 || b.days > math.MaxInt32 ||
+		b.nanos > math.MaxInt64) {
+		return Duration{}, fmt.Errorf("duration out of range")
+	}
+	d := Duration{
+		Months:      int32(b.months),
+		Days:        int32(b.days),
+		Nanoseconds: int64(b.nanos),
+	}
+	if negative {
+		d = Duration{-d.Months, -d.Days, -d.Nanoseconds}
+	}
+	return d, nil
+}
+
+// the CQL units in the order they must appear
+var cqlDurationUnits = []string{"y", "mo", "w", "d", "h", "m", "s", "ms", "us", "ns"}
+
+func (b *durationBuilder) parseCQL(s string) error {
+	next := 0
+	for s != "" {
+		digits := leadingDigits(s)
+		if digits == 0 {
+			return fmt.Errorf("expected a number at %q", s)
+		}
+		n, err := strconv.ParseUint(s[:digits], 10, 64)
+		if err != nil {
+			return err
+		}
+		s = s[digits:]
+
+		unitLen := 0
+		for unitLen < len(s) && !isDigit(s[unitLen]) {
+			unitLen++
+		}
+		unit := strings.ToLower(s[:unitLen])
+		if unit == "µs" {
+			unit = "us"
+		}
+		s = s[unitLen:]
+
+		pos := -1
+		for i, u := range cqlDurationUnits {
+			if u == unit {
+				pos = i
+				break
+			}
+		}
+		if pos < 0 {
+			return fmt.Errorf("unknown unit %q", unit)
+		}
+		if pos < next {
+			return fmt.Errorf("unit %q is repeated or out of order", unit)
+		}
+		next = pos + 1
+
+		switch unit {
+		case "y":
+			err = b.addMonths(n, 12)
+		case "mo":
+			err = b.addMonths(n, 1)
+		case "w":
+			err = b.addDays(n, 7)
+		case "d":
+			err = b.addDays(n, 1)
+		default:
+			for _, u := range durationUnits {
+				if u.unit == unit {
+					err = b.addNanos(n, uint64(u.nanos))
+				}
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parses an ISO 8601 duration without the leading P
+func (b *durationBuilder) parseISO(s string) error {
+	if s == "" {
+		return fmt.Errorf("missing duration after P")
+	}
+	if strings.HasSuffix(s, "W") {
+		n, err := strconv.ParseUint(s[:len(s)-1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number of weeks")
+		}
+		return b.addDays(n, 7)
+	}
+	if strings.Contains(s, "-") || strings.Contains(s, ":") {
+		return b.parseISOAlternative(s)
+	}
+
+	date, clock := s, ""
+	if i := strings.IndexByte(s, 'T'); i >= 0 {
+		date, clock = s[:i], s[i+1:]
+		if clock == "" {
+			return fmt.Errorf("missing time after T")
+		}
+	}
+	if err := b.parseISOSection(date, "YMD"); err != nil {
+		return err
+	}
+	return b.parseISOSection(clock, "HMS")
+}
+
+func (b *durationBuilder) parseISOSection(s string, designators string) error {
+	next := 0
+	for s != "" {
+		digits := leadingDigits(s)
+		if digits == 0 || digits == len(s) {
+			return fmt.Errorf("expected a number and a designator at %q", s)
+		}
+		n, err := strconv.ParseUint(s[:digits], 10, 64)
+		if err != nil {
+			return err
+		}
+		pos := strings.IndexByte(designators[next:], s[digits])
+		if pos < 0 {
+			return fmt.Errorf("unexpected designator %q", s[digits])
+		}
+		pos += next
+		next = pos + 1
+		s = s[digits+1:]
+
+		date := designators == "YMD"
+		switch c := designators[pos]; {
+		case date && c == 'Y':
+			err = b.addMonths(n, 12)
+		case date && c == 'M':
+			err = b.addMonths(n, 1)
+		case c == 'D':
+			err = b.addDays(n, 1)
+		case c == 'H':
+			err = b.addNanos(n, uint64(time.Hour))
+		case c == 'M':
+			err = b.addNanos(n, uint64(time.Minute))
+		case c == 'S':
+			err = b.addNanos(n, uint64(time.Second))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parses YYYY-MM-DDThh:mm:ss
+func (b *durationBuilder) parseISOAlternative(s string) error {
+	var y, mo, d, h, m, sec uint64
+	n, err := fmt.Sscanf(s, "%4d-%2d-%2dT%2d:%2d:%2d", &y, &mo, &d, &h, &m, &sec)
+	if err != nil || n != 6 || len(s) != len("YYYY-MM-DDThh:mm:ss") {
+		return fmt.Errorf("expected the format YYYY-MM-DDThh:mm:ss")
+	}
+	if err := b.addMonths(y, 12); err != nil {
+		return err
+	}
+	if err := b.addMonths(mo, 1); err != nil {
+		return err
+	}
+	if err := b.addDays(d, 1); err != nil {
+		return err
+	}
+	if err := b.addNanos(h, uint64(time.Hour)); err != nil {
+		return err
+	}
+	if err := b.addNanos(m, uint64(time.Minute)); err != nil {
+		return err
+	}
+	return b.addNanos(sec, uint64(time.Second))
+}
+
+func leadingDigits(s string) int {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return i
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
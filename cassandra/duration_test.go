@@ -0,0 +1,145 @@
+package cassandra_test
+
+import (
+	"golang-driver/cassandra"
+	"golang-driver/cassandra/test"
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	hour := int64(time.Hour)
+	cases := []struct {
+		str      string
+		expected cassandra.Duration
+	}{
+		{"1y2mo3d", cassandra.Duration{14, 3, 0}},
+		{"1h30m", cassandra.Duration{0, 0, hour + 30*int64(time.Minute)}},
+		{"2w", cassandra.Duration{0, 14, 0}},
+		{"-3d4h", cassandra.Duration{0, -3, -4 * hour}},
+		{"1Y2MO", cassandra.Duration{14, 0, 0}},
+		{"5ms6us7ns", cassandra.Duration{0, 0, 5006007}},
+		{"8µs", cassandra.Duration{0, 0, 8000}},
+		{"P1Y2M3DT4H5M6S", cassandra.Duration{14, 3, 4*hour + 5*int64(time.Minute) + 6*int64(time.Second)}},
+		{"PT10M", cassandra.Duration{0, 0, 10 * int64(time.Minute)}},
+		{"P3W", cassandra.Duration{0, 21, 0}},
+		{"-P1D", cassandra.Duration{0, -1, 0}},
+		{"P0001-02-03T04:05:06", cassandra.Duration{14, 3, 4*hour + 5*int64(time.Minute) + 6*int64(time.Second)}},
+	}
+	for _, c := range cases {
+		d, err := cassandra.ParseDuration(c.str)
+		if err != nil {
+			t.Errorf("%s: %s", c.str, err)
+			continue
+		}
+		if d != c.expected {
+			t.Errorf("%s: expected %v, got %v", c.str, c.expected, d)
+		}
+	}
+}
+
+func TestParseInvalidDuration(t *testing.T) {
+	for _, str := range []string{"", "-", "1", "h", "1h2d", "1h1h", "3x", "P", "PT", "P1H", "PT1D", "P1Y-2M", "9223372036854775808ns"} {
+		if d, err := cassandra.ParseDuration(str); err == nil {
+			t.Errorf("%q should not be a valid duration (got %v)", str, d)
+		}
+	}
+	if d, err := cassandra.ParseDuration("-9223372036854775808ns"); err != nil {
+		t.Errorf("the minimum duration should be valid: %s", err)
+	} else if d.Nanoseconds != -9223372036854775808 {
+		t.Errorf("expected the minimum nanoseconds, got %d", d.Nanoseconds)
+	}
+}
+
+func TestDurationString(t *testing.T) {
+	cases := map[string]cassandra.Duration{
+		"0s":                     {},
+		"1y2mo3d":                {14, 3, 0},
+		"1h30m":                  {0, 0, int64(90 * time.Minute)},
+		"-1d1ms":                 {0, -1, -int64(time.Millisecond)},
+		"1y1mo1d1h1m1s1ms1us1ns": {13, 1, int64(time.Hour + time.Minute + time.Second + time.Millisecond + time.Microsecond + 1)},
+	}
+	for expected, d := range cases {
+		if s := d.String(); s != expected {
+			t.Errorf("expected %s, got %s", expected, s)
+		}
+		parsed, err := cassandra.ParseDuration(d.NativeString())
+		if err != nil || parsed != d {
+			t.Errorf("%s does not round-trip: %v (%v)", expected, parsed, err)
+		}
+	}
+}
+
+func TestDurationConversions(t *testing.T) {
+	d := cassandra.FromDuration(90 * time.Minute)
+	if td, exact := d.Duration(); !exact || td != 90*time.Minute {
+		t.Errorf("expected 90m, got %s (exact: %v)", td, exact)
+	}
+	if _, exact := (cassandra.Duration{Days: 1}).Duration(); exact {
+		t.Error("a duration with days cannot be converted exactly")
+	}
+	if _, err := cassandra.NewDuration(1, -1, 0); err == nil {
+		t.Error("components with different signs should not be accepted")
+	}
+
+	start := time.Date(2016, 1, 31, 0, 0, 0, 0, time.UTC)
+	end := cassandra.Duration{1, 1, int64(time.Hour)}.AddTo(start)
+	if expected := time.Date(2016, 3, 3, 1, 0, 0, 0, time.UTC); !end.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, end)
+	}
+}
+
+func TestDurations(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	if err := test.Setup(durationsSetup); err != nil {
+		t.Log("Unexpected error while setup. You might need to clean up manuallly golang_driver keyspace")
+		t.Fatal(err)
+	}
+	defer test.TearDown(durationsCleanup)
+
+	pstmt, err := session.Prepare("INSERT INTO golang_driver.durations (id, d, dlist) VALUES (?, ?, ?)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pstmt.Close()
+
+	d := cassandra.Duration{14, 3, int64(time.Hour)}
+	dlist := []cassandra.Duration{{0, 1, 0}, cassandra.FromDuration(time.Minute)}
+	if _, err := pstmt.Exec(int32(1), d, dlist); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := session.Exec("SELECT d, dlist FROM golang_driver.durations WHERE id = 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		t.Fatal("expected a row")
+	}
+	var rd cassandra.Duration
+	var rlist []cassandra.Duration
+	if err := rows.Scan(&rd, &rlist); err != nil {
+		t.Fatal(err)
+	}
+	if rd != d {
+		t.Errorf("expected %s, got %s", d, rd)
+	}
+	if len(rlist) != 2 || rlist[0] != dlist[0] || rlist[1] != dlist[1] {
+		t.Errorf("expected %v, got %v", dlist, rlist)
+	}
+}
+
+var (
+	durationsSetup = []string{
+		"CREATE KEYSPACE IF NOT EXISTS golang_driver WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};",
+		`CREATE TABLE IF NOT EXISTS golang_driver.durations(id int PRIMARY KEY, d duration, dlist list<duration>)`,
+	}
+
+	durationsCleanup = []string{
+		"DROP TABLE golang_driver.durations",
+	}
+)
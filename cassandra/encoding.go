@@ -0,0 +1,264 @@
+package cassandra
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"reflect"
+	"time"
+)
+
+// The driver's types implement encoding.TextMarshaler and
+// json.Marshaler, and their Unmarshaler counterparts (Tuple is only
+// decoded from JSON), using the same representations as their
+// String() methods, except for Timestamp which uses RFC 3339.
+
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+func (u *UUID) UnmarshalText(text []byte) error {
+	parsed, err := ParseUUID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+func (u UUID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.String())
+}
+
+func (u *UUID) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, u)
+}
+
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Date) UnmarshalText(text []byte) error {
+	parsed, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, d)
+}
+
+func (t Time) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *Time) UnmarshalText(text []byte) error {
+	parsed, err := ParseTime(string(text))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+func (t Time) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+func (t *Time) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, t)
+}
+
+func (t Timestamp) MarshalText() ([]byte, error) {
+	return []byte(t.Time().Format(time.RFC3339Nano)), nil
+}
+
+func (t *Timestamp) UnmarshalText(text []byte) error {
+	parsed, err := time.Parse(time.RFC3339Nano, string(text))
+	if err != nil {
+		return err
+	}
+	*t = NewTimestampFromTime(parsed)
+	return nil
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	text, _ := t.MarshalText()
+	return json.Marshal(string(text))
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, t)
+}
+
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalText(text []byte) error {
+	parsed, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = *parsed
+	return nil
+}
+
+// Decimals are encoded as JSON strings to preserve their precision.
+// Both strings and numbers are accepted when decoding.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	text, _ := d.MarshalText()
+	return json.Marshal(string(text))
+}
+
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] != '"' && !bytes.Equal(data, []byte("null")) {
+		return d.UnmarshalText(data)
+	}
+	return unmarshalJSONText(data, d)
+}
+
+// Returns the representation of the tuple in CQL (see NativeString).
+func (tuple Tuple) MarshalText() ([]byte, error) {
+	return []byte(tuple.NativeString()), nil
+}
+
+// Tuples are encoded as JSON arrays.
+func (tuple Tuple) MarshalJSON() ([]byte, error) {
+	if tuple.values == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(tuple.values)
+}
+
+// Decodes a JSON array into the tuple. When the tuple was created
+// with NewTuple its elements are decoded into the Go types of its
+// subtypes (the types they're read into when scanning an interface{}),
+// otherwise into the types chosen by encoding/json.
+func (tuple *Tuple) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if tuple.kind.primary != CASS_VALUE_TYPE_TUPLE || len(tuple.kind.subtypes) == 0 {
+		subtypes := make([]CassType, len(raw))
+		for i := range subtypes {
+			subtypes[i] = CUnknown
+		}
+		*tuple = *NewTuple(CTuple.Specialize(subtypes...))
+	}
+	if len(raw) != tuple.Len() {
+		return fmt.Errorf("cannot decode %d values into a tuple %s which has %d values",
+			len(raw), tuple.kind.String(), tuple.Len())
+	}
+	for i, st := range tuple.kind.subtypes {
+		goType := reflect.TypeOf((*interface{})(nil)).Elem()
+		if !st.Equals(CUnknown) {
+			t, err := goTypeFor(st)
+			if err != nil {
+				return err
+			}
+			goType = t
+		}
+		v := reflect.New(goType)
+		if err := json.Unmarshal(raw[i], v.Interface()); err != nil {
+			return err
+		}
+		tuple.values[i] = v.Elem().Interface()
+	}
+	return nil
+}
+
+// decodes a JSON string (or null, which is ignored like encoding/json
+// does) with the UnmarshalText method of dst
+func unmarshalJSONText(data []byte, dst interface {
+	UnmarshalText(text []byte) error
+}) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return dst.UnmarshalText([]byte(s))
+}
+
+// the types handled by the built-in conversions even though they
+// implement the encoding or database/sql interfaces
+var builtinTypes = map[reflect.Type]struct{}{
+	reflect.TypeOf(UUID{}):      {},
+	reflect.TypeOf(Date{}):      {},
+	reflect.TypeOf(Time(0)):     {},
+	reflect.TypeOf(Timestamp{}): {},
+	reflect.TypeOf(Decimal{}):   {},
+	reflect.TypeOf(Tuple{}):     {},
+	reflect.TypeOf(net.IP{}):    {},
+	reflect.TypeOf(big.Int{}):   {},
+	reflect.TypeOf(time.Time{}): {},
+}
+
+// reports whether values of the type (or pointers to them) are
+// converted by the built-in conversions
+func isBuiltinType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	_, ok := builtinTypes[t]
+	return ok
+}
+
+// Converts a value read into an interface{} (see CassType.GoType) into
+// one of the types of database/sql/driver values, which are passed to
+// sql.Scanner.Scan: int64, float64, bool, []byte, string or time.Time.
+// Timestamps and dates become times, and the values of uuid, decimal,
+// varint, inet, time and duration columns their CQL text. Collections
+// and tuples are returned as they are.
+func DriverValue(native interface{}) interface{} {
+	switch v := native.(type) {
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int:
+		return int64(v)
+	case float32:
+		return float64(v)
+	case Timestamp:
+		return v.Time()
+	case Date:
+		return v.Time()
+	case Time, UUID, *Decimal, *big.Int, net.IP, Duration:
+		return v.(fmt.Stringer).String()
+	}
+	return native
+}
+
+// returns the text passed to encoding.TextUnmarshaler.UnmarshalText
+// for a native value
+func nativeText(native interface{}) ([]byte, error) {
+	switch v := native.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case encoding.TextMarshaler:
+		return v.MarshalText()
+	}
+	return []byte(fmt.Sprint(native)), nil
+}
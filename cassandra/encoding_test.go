@@ -0,0 +1,182 @@
+package cassandra_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"golang-driver/cassandra"
+	"golang-driver/cassandra/test"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONEncoding(t *testing.T) {
+	u, _ := cassandra.ParseUUID("6f5e2d3c-1b0a-4f9e-8d7c-6b5a49382716")
+	tm, _ := cassandra.NewTime(10, 15, 20, 0)
+	value := struct {
+		U  cassandra.UUID
+		D  cassandra.Date
+		T  cassandra.Time
+		TS cassandra.Timestamp
+		N  *cassandra.Decimal
+	}{
+		u,
+		mustDate(2016, 2, 29),
+		tm,
+		cassandra.NewTimestampFromTime(time.Date(2015, 12, 20, 10, 11, 39, 0, time.UTC)),
+		cassandra.NewDecimal(1234, 2),
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"U":"6f5e2d3c-1b0a-4f9e-8d7c-6b5a49382716","D":"2016-02-29","T":"10:15:20.000000000","TS":"2015-12-20T10:11:39Z","N":"12.34"}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+
+	decoded := value
+	decoded.U, decoded.D, decoded.T, decoded.TS, decoded.N = cassandra.UUID{}, cassandra.Date{}, 0, cassandra.Timestamp{}, nil
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.U != value.U || decoded.D != value.D || decoded.T != value.T ||
+		decoded.TS != value.TS || decoded.N.String() != value.N.String() {
+		t.Errorf("expected %v, got %v", value, decoded)
+	}
+
+	var d cassandra.Decimal
+	if err := json.Unmarshal([]byte("-0.5"), &d); err != nil || d.Value.Int64() != -5 || d.Scale != 1 {
+		t.Errorf("expected -0.5 from a JSON number, got %v (%v)", d, err)
+	}
+}
+
+func TestTupleJSON(t *testing.T) {
+	kind := cassandra.CTuple.Specialize(cassandra.CInt, cassandra.CText, cassandra.CUuid)
+	u := cassandra.NewRandomUUID()
+	tuple := cassandra.NewTuple(kind, 1, "abc", u)
+	data, err := json.Marshal(tuple)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := fmt.Sprintf(`[1,"abc","%s"]`, u); string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+
+	decoded := cassandra.NewTuple(kind)
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Get(0) != 1 || decoded.Get(1) != "abc" || decoded.Get(2) != u {
+		t.Errorf("expected %v, got %v", tuple.Values(), decoded.Values())
+	}
+	if err := json.Unmarshal([]byte(`[1, "abc"]`), decoded); err == nil {
+		t.Error("expected an error when decoding 2 values into a tuple of 3")
+	}
+
+	var untyped cassandra.Tuple
+	if err := json.Unmarshal([]byte(`[true, "x"]`), &untyped); err != nil {
+		t.Fatal(err)
+	}
+	if untyped.Len() != 2 || untyped.Get(0) != true || untyped.Get(1) != "x" {
+		t.Errorf("expected [true x], got %v", untyped.Values())
+	}
+}
+
+// a database/sql style type, stored as text
+type Email struct {
+	User, Domain string
+}
+
+func (e Email) Value() (driver.Value, error) {
+	if e.User == "" {
+		return nil, nil
+	}
+	return e.User + "@" + e.Domain, nil
+}
+
+func (e *Email) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		*e = Email{}
+	case string:
+		parts := strings.SplitN(src, "@", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid email %q", src)
+		}
+		e.User, e.Domain = parts[0], parts[1]
+	default:
+		return fmt.Errorf("cannot scan %T into an Email", src)
+	}
+	return nil
+}
+
+// an identifier type stored as uuid, converted through its text
+type AccountID struct {
+	uuid cassandra.UUID
+}
+
+func (id AccountID) MarshalText() ([]byte, error) {
+	return id.uuid.MarshalText()
+}
+
+func (id *AccountID) UnmarshalText(text []byte) error {
+	return id.uuid.UnmarshalText(text)
+}
+
+func TestSQLAndTextInterfaces(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	if err := test.Setup(encodingSetup); err != nil {
+		t.Log("Unexpected error while setup. You might need to clean up manually golang_driver keyspace")
+		t.Fatal(err)
+	}
+	defer test.TearDown(encodingCleanup)
+
+	pstmt, err := session.Prepare("INSERT INTO golang_driver.accounts (id, email, backup, created) VALUES (?, ?, ?, ?)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pstmt.Close()
+
+	id := AccountID{cassandra.NewRandomUUID()}
+	email := Email{"jane", "example.com"}
+	created := time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC)
+	if _, err := pstmt.Exec(id, email, Email{}, created); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := session.Exec("SELECT id, email, backup, created FROM golang_driver.accounts WHERE id = ?", id.uuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		t.Fatal("expected a row")
+	}
+	var rid AccountID
+	var remail Email
+	backup := Email{"old", "example.com"}
+	var rcreated time.Time
+	if err := rows.Scan(&rid, &remail, &backup, &rcreated); err != nil {
+		t.Fatal(err)
+	}
+	if rid != id || remail != email || backup != (Email{}) || !rcreated.Equal(created) {
+		t.Errorf("expected (%v, %v, {}, %s), got (%v, %v, %v, %s)", id, email, created,
+			rid, remail, backup, rcreated)
+	}
+}
+
+var (
+	encodingSetup = []string{
+		"CREATE KEYSPACE IF NOT EXISTS golang_driver WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};",
+		`CREATE TABLE IF NOT EXISTS golang_driver.accounts(id uuid PRIMARY KEY, email text, backup text, created timestamp)`,
+	}
+
+	encodingCleanup = []string{
+		"DROP TABLE golang_driver.accounts",
+	}
+)
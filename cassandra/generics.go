@@ -0,0 +1,273 @@
+package cassandra
+
+import (
+	"database/sql"
+	"encoding"
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+	"strings"
+)
+
+// Returned by QueryOne and ScanOne when the result has no rows.
+var ErrNoRows = errors.New("no rows in result")
+
+// The generic functions below read rows into values of type T. If T
+// is a struct (or a pointer to a struct), each column is read into
+// the field of the same name: the name of a field is the name in its
+// `cql` tag if it has one, or its name in lower case otherwise. Fields
+// tagged with `cql:"-"` are ignored, the fields of embedded structs
+// (but not of embedded pointers) are promoted and every column must
+// match a field.
+//
+// The structs this package reads values into (Timestamp, Date, etc.),
+// the types implementing sql.Scanner or encoding.TextUnmarshaler and
+// the types with a registered Codec aren't mapped that way: like any
+// other type they're read from the only column of the result.
+
+// Executes the query and reads its first row into a T. Returns
+// ErrNoRows if the result is empty.
+func QueryOne[T any](session *Session, query string, args ...interface{}) (T, error) {
+	rows, err := session.Exec(query, args...)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	defer rows.Close()
+	return ScanOne[T](rows)
+}
+
+// Executes the query and reads all of its rows into Ts.
+func QueryAll[T any](session *Session, query string, args ...interface{}) ([]T, error) {
+	rows, err := session.Exec(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return ScanAll[T](rows)
+}
+
+// Returns an iterator executing the query and reading its rows into
+// Ts. The iteration stops after the first error.
+func QueryIter[T any](session *Session, query string, args ...interface{}) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		rows, err := session.Exec(query, args...)
+		if err != nil {
+			var zero T
+			yield(zero, err)
+			return
+		}
+		defer rows.Close()
+		for v, err := range ScanIter[T](rows) {
+			if !yield(v, err) {
+				return
+			}
+		}
+	}
+}
+
+// Reads the next row into a T. Returns ErrNoRows if there are no more
+// rows.
+func ScanOne[T any](rows *Rows) (T, error) {
+	var v T
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return v, err
+		}
+		return v, ErrNoRows
+	}
+	m, err := newRowMapping(reflect.TypeOf(&v).Elem(), rows)
+	if err != nil {
+		return v, err
+	}
+	err = m.scan(rows, reflect.ValueOf(&v).Elem())
+	return v, err
+}
+
+// Reads the remaining rows into Ts.
+func ScanAll[T any](rows *Rows) ([]T, error) {
+	var all []T
+	for v, err := range ScanIter[T](rows) {
+		if err != nil {
+			return all, err
+		}
+		all = append(all, v)
+	}
+	return all, nil
+}
+
+// Returns an iterator reading the remaining rows into Ts. The
+// iteration stops after the first error.
+func ScanIter[T any](rows *Rows) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var m *rowMapping
+		for rows.Next() {
+			var v T
+			if m == nil {
+				var err error
+				if m, err = newRowMapping(reflect.TypeOf(&v).Elem(), rows); err != nil {
+					yield(v, err)
+					return
+				}
+			}
+			if err := m.scan(rows, reflect.ValueOf(&v).Elem()); err != nil {
+				yield(v, err)
+				return
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// how the columns of a result are read into a Go type
+type rowMapping struct {
+	// true if the type is a pointer to the struct
+	ptr bool
+	// the index of the field of each column or nil to read the only
+	// column into the value itself
+	fields [][]int
+}
+
+var (
+	scannerType         = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	nullableDstType     = reflect.TypeOf((*nullableDst)(nil)).Elem()
+)
+
+// returns true if the columns are read into the fields of t
+func isMappedStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || isBuiltinType(t) {
+		return false
+	}
+	if _, ok := lookupCodec(t); ok {
+		return false
+	}
+	ptr := reflect.PointerTo(t)
+	return !ptr.Implements(scannerType) && !ptr.Implements(textUnmarshalerType) &&
+		!ptr.Implements(nullableDstType)
+}
+
+func newRowMapping(t reflect.Type, rows *Rows) (*rowMapping, error) {
+	columns := int(rows.ColumnCount())
+	m := &rowMapping{}
+	if t.Kind() == reflect.Ptr && isMappedStruct(t.Elem()) {
+		m.ptr = true
+		t = t.Elem()
+	}
+	if !isMappedStruct(t) {
+		if columns != 1 {
+			return nil, fmt.Errorf("cannot read %d columns into %s", columns, t.String())
+		}
+		return m, nil
+	}
+
+	fields := make(map[string][]int)
+	collectFields(t, nil, fields)
+	m.fields = make([][]int, columns)
+	for i := range m.fields {
+		name := rows.ColumnName(i)
+		index, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("no field of %s matches column %s", t.String(), name)
+		}
+		m.fields[i] = index
+	}
+	return m, nil
+}
+
+// adds the exported fields of t (and of its embedded structs) by
+// column name; the fields of t hide the fields of the embedded structs
+func collectFields(t reflect.Type, parent []int, fields map[string][]int) {
+	var embedded []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		// the tag can have options after the name (see the mapper package)
+		tag, _, _ := strings.Cut(field.Tag.Get("cql"), ",")
+		if tag == "-" {
+			continue
+		}
+		if field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct && isMappedStruct(field.Type) {
+			embedded = append(embedded, field)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		name := tag
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		if _, ok := fields[name]; !ok {
+			fields[name] = append(append([]int{}, parent...), i)
+		}
+	}
+	for _, field := range embedded {
+		collectFields(field.Type, append(append([]int{}, parent...), field.Index...), fields)
+	}
+}
+
+// reads the current row into dst, which is addressable
+func (m *rowMapping) scan(rows *Rows, dst reflect.Value) error {
+	if m.fields == nil {
+		return rows.Scan(dst.Addr().Interface())
+	}
+	if m.ptr {
+		dst.Set(reflect.New(dst.Type().Elem()))
+		dst = dst.Elem()
+	}
+	args := make([]interface{}, len(m.fields))
+	for i, index := range m.fields {
+		args[i] = dst.FieldByIndex(index).Addr().Interface()
+	}
+	return rows.Scan(args...)
+}
+
+// A set of values of a CQL `set`. It can be bound to and read from
+// `set` columns, and it's bound as a set when the type of the column
+// isn't known (unlike other maps).
+type SetOf[T comparable] map[T]struct{}
+
+// Creates a set holding the given values.
+func NewSetOf[T comparable](values ...T) SetOf[T] {
+	s := make(SetOf[T], len(values))
+	for _, v := range values {
+		s[v] = struct{}{}
+	}
+	return s
+}
+
+func (s SetOf[T]) Add(v T) {
+	s[v] = struct{}{}
+}
+
+func (s SetOf[T]) Remove(v T) {
+	delete(s, v)
+}
+
+func (s SetOf[T]) Contains(v T) bool {
+	_, ok := s[v]
+	return ok
+}
+
+// Returns the values of the set, in no particular order.
+func (s SetOf[T]) Values() []T {
+	values := make([]T, 0, len(s))
+	for v := range s {
+		values = append(values, v)
+	}
+	return values
+}
+
+func (s SetOf[T]) isSet() {}
+
+// implemented by SetOf
+type anySet interface {
+	isSet()
+}
@@ -0,0 +1,134 @@
+package cassandra_test
+
+import (
+	"golang-driver/cassandra"
+	"golang-driver/cassandra/test"
+	"sort"
+	"testing"
+)
+
+type audit struct {
+	Author string
+}
+
+type book struct {
+	audit
+	ID       int32  `cql:"id"`
+	Title    string `cql:"title"`
+	Year     cassandra.NullInt32
+	Tags     cassandra.SetOf[string]
+	Internal string `cql:"-"`
+}
+
+func TestSetOf(t *testing.T) {
+	s := cassandra.NewSetOf("a", "b", "a")
+	s.Add("c")
+	s.Remove("b")
+	if len(s) != 2 || !s.Contains("a") || !s.Contains("c") || s.Contains("b") {
+		t.Errorf("unexpected set %v", s)
+	}
+	values := s.Values()
+	sort.Strings(values)
+	if len(values) != 2 || values[0] != "a" || values[1] != "c" {
+		t.Errorf("unexpected values %v", values)
+	}
+}
+
+func TestGenericQueries(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	if err := test.Setup(genericsSetup); err != nil {
+		t.Log("Unexpected error while setup. You might need to clean up manually golang_driver keyspace")
+		t.Fatal(err)
+	}
+	defer test.TearDown(genericsCleanup)
+
+	// a SetOf is bound as a set even with simple statements
+	if _, err := session.Exec("INSERT INTO golang_driver.books (id, title, author, year, tags) VALUES (?, ?, ?, ?, ?)",
+		int32(1), "Dune", "Herbert", int32(1965), cassandra.NewSetOf("sf", "classic")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := session.Exec("INSERT INTO golang_driver.books (id, title, author) VALUES (?, ?, ?)",
+		int32(2), "Untitled", "Anonymous"); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := cassandra.QueryOne[book](session,
+		"SELECT id, title, author, year, tags FROM golang_driver.books WHERE id = ?", int32(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.ID != 1 || b.Title != "Dune" || b.Author != "Herbert" || b.Year != (cassandra.NullInt32{1965, true}) ||
+		len(b.Tags) != 2 || !b.Tags.Contains("sf") || !b.Tags.Contains("classic") {
+		t.Errorf("unexpected %+v", b)
+	}
+
+	if _, err := cassandra.QueryOne[*book](session,
+		"SELECT id FROM golang_driver.books WHERE id = ?", int32(3)); err != cassandra.ErrNoRows {
+		t.Errorf("expected ErrNoRows, got %v", err)
+	}
+	if _, err := cassandra.QueryOne[book](session,
+		"SELECT id, writetime(title) FROM golang_driver.books WHERE id = ?", int32(1)); err == nil {
+		t.Error("a column without a field should be an error")
+	}
+
+	all, err := cassandra.QueryAll[*book](session, "SELECT id, title, year FROM golang_driver.books")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("expected 2 books, got %d", len(all))
+	}
+	for _, b := range all {
+		if b.ID == 2 && (b.Title != "Untitled" || b.Year.Valid) {
+			t.Errorf("unexpected %+v", b)
+		}
+	}
+
+	// single columns
+	title, err := cassandra.QueryOne[string](session,
+		"SELECT title FROM golang_driver.books WHERE id = ?", int32(2))
+	if err != nil || title != "Untitled" {
+		t.Errorf("expected Untitled, got %s (%v)", title, err)
+	}
+	var ids []int32
+	for id, err := range cassandra.QueryIter[int32](session, "SELECT id FROM golang_driver.books") {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) != 2 {
+		t.Errorf("expected 2 ids, got %v", ids)
+	}
+	if _, err := cassandra.QueryAll[string](session, "SELECT id, title FROM golang_driver.books"); err == nil {
+		t.Error("reading 2 columns into a string should be an error")
+	}
+
+	pstmt, err := session.Prepare("SELECT tags FROM golang_driver.books WHERE id = ?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pstmt.Close()
+	rows, err := pstmt.Exec(int32(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	tags, err := cassandra.ScanOne[cassandra.SetOf[string]](rows)
+	if err != nil || len(tags) != 2 || !tags.Contains("classic") {
+		t.Errorf("unexpected %v (%v)", tags, err)
+	}
+}
+
+var (
+	genericsSetup = []string{
+		"CREATE KEYSPACE IF NOT EXISTS golang_driver WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};",
+		`CREATE TABLE IF NOT EXISTS golang_driver.books(id int PRIMARY KEY, title text, author text, year int, tags set<text>)`,
+	}
+
+	genericsCleanup = []string{
+		"DROP TABLE golang_driver.books",
+	}
+)
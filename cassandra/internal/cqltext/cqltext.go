@@ -0,0 +1,88 @@
+// Package cqltext holds the lexical rules of CQL shared by the
+// packages generating or parsing CQL text.
+package cqltext
+
+import (
+	"regexp"
+	"strings"
+)
+
+var unquotedIdentifier = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// the reserved keywords, which must be quoted to be used as identifiers
+var reservedKeywords = map[string]bool{}
+
+func init() {
+	for _, k := range strings.Fields(`add allow alter and apply asc authorize batch
+		begin by columnfamily create default delete desc describe drop entries
+		execute from full grant if in index infinity insert into is keyspace
+		limit materialized mbean mbeans modify nan norecursive not null of on
+		or order primary rename replace revoke schema select set table to token
+		truncate unlogged unset update use using view where with`) {
+		reservedKeywords[k] = true
+	}
+}
+
+// Returns the identifier as it must be written in CQL: unchanged if
+// it's lower case and not a reserved keyword, or double quoted (e.g.
+// "userId" or "order") otherwise.
+func QuoteIdentifier(name string) string {
+	if unquotedIdentifier.MatchString(name) && !reservedKeywords[name] {
+		return name
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// Quotes a name which may be qualified by a keyspace (e.g.
+// keyspace.table).
+func QuoteName(name string) string {
+	if keyspace, rest, ok := strings.Cut(name, "."); ok {
+		return QuoteIdentifier(keyspace) + "." + QuoteIdentifier(rest)
+	}
+	return QuoteIdentifier(name)
+}
+
+// Returns the string as a CQL string literal, in which the quotes are
+// doubled.
+func QuoteString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// Returns the position following the string literal ('...' or
+// $$...$$), quoted identifier or comment (-- or // to the end of the
+// line, /* ... */) starting at text[i], or i if there's none there.
+// Unterminated ones end with the text.
+func Skip(text string, i int) int {
+	rest := text[i:]
+	end := func(terminator string, from int) int {
+		if j := strings.Index(rest[from:], terminator); j >= 0 {
+			return i + from + j + len(terminator)
+		}
+		return len(text)
+	}
+	switch {
+	case rest == "":
+		return i
+	case rest[0] == '\'' || rest[0] == '"':
+		// the quote is escaped by doubling it
+		quote := rest[:1]
+		for j := 1; j < len(rest); j++ {
+			if rest[j] != quote[0] {
+				continue
+			}
+			if j+1 < len(rest) && rest[j+1] == quote[0] {
+				j++
+				continue
+			}
+			return i + j + 1
+		}
+		return len(text)
+	case strings.HasPrefix(rest, "$$"):
+		return end("$$", 2)
+	case strings.HasPrefix(rest, "--"), strings.HasPrefix(rest, "//"):
+		return end("\n", 2)
+	case strings.HasPrefix(rest, "/*"):
+		return end("*/", 2)
+	}
+	return i
+}
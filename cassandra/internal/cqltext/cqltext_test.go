@@ -0,0 +1,71 @@
+package cqltext_test
+
+import (
+	"golang-driver/cassandra/internal/cqltext"
+	"testing"
+)
+
+func TestQuoteIdentifier(t *testing.T) {
+	for name, quoted := range map[string]string{
+		"id":        "id",
+		"user_id2":  "user_id2",
+		"userId":    `"userId"`,
+		"order":     `"order"`,
+		"2fa":       `"2fa"`,
+		`say "hi"`:  `"say ""hi"""`,
+		"":          `""`,
+		"é":         `"é"`,
+		"key":       "key",
+		"token":     `"token"`,
+		"with_TTL":  `"with_TTL"`,
+		"ks_1.tbl":  `"ks_1.tbl"`,
+		"_internal": `"_internal"`,
+	} {
+		if actual := cqltext.QuoteIdentifier(name); actual != quoted {
+			t.Errorf("%q: expected %s, got %s", name, quoted, actual)
+		}
+	}
+}
+
+func TestQuoteString(t *testing.T) {
+	for s, quoted := range map[string]string{
+		"":          "''",
+		"abc":       "'abc'",
+		"it's":      "'it''s'",
+		"''":        "''''''",
+		`"quoted"`:  `'"quoted"'`,
+		"two\nline": "'two\nline'",
+	} {
+		if actual := cqltext.QuoteString(s); actual != quoted {
+			t.Errorf("%q: expected %s, got %s", s, quoted, actual)
+		}
+	}
+}
+
+func TestSkip(t *testing.T) {
+	for _, test := range []struct {
+		text     string
+		i        int
+		expected int
+	}{
+		{"SELECT 1", 0, 0},
+		{"a = 'x' AND", 4, 7},
+		{"a = 'it''s' AND", 4, 11},
+		{`"My""Col" = ?`, 0, 9},
+		{"x $$ a ' b $$;", 2, 13},
+		{"-- comment\nSELECT", 0, 11},
+		{"// comment\nSELECT", 0, 11},
+		{"a /* x \n y */ b", 2, 13},
+		{"'unterminated", 0, 13},
+		{"/* unterminated", 0, 15},
+		{"-- last line", 0, 12},
+		{"a - b", 2, 2},
+		{"a / b", 2, 2},
+		{"$1", 0, 0},
+		{"", 0, 0},
+	} {
+		if actual := cqltext.Skip(test.text, test.i); actual != test.expected {
+			t.Errorf("%q at %d: expected %d, got %d", test.text, test.i, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package cassandra
+
+import (
+	"encoding/hex"
+	"fmt"
+	"golang-driver/cassandra/internal/cqltext"
+	"math"
+	"math/big"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Returns the value as a CQL literal of the type, e.g. 'abc' for
+// text, 0xcafe for blob, {1: 'one'} for map<int, text> or null for
+// nil. The value is converted as when it's bound to a statement, so
+// that the literal stores the same value. When the type is CUnknown
+// (or a collection of unknown elements), it's inferred from the Go
+// value as for simple statements. The values of UDTs are given as maps
+// of the names of their fields to their values.
+func (ct CassType) Literal(value interface{}) (string, error) {
+	value, ct, err := nativeValue(value, ct)
+	if err != nil {
+		return "", err
+	}
+	if value == nil {
+		return "null", nil
+	}
+	if ct.primary == CASS_VALUE_TYPE_UNKNOWN || ct.primary == CASS_VALUE_TYPE_CUSTOM {
+		if ct, err = cassTypeOf(value); err != nil {
+			return "", err
+		}
+	}
+
+	switch ct.primary {
+	case CASS_VALUE_TYPE_LIST:
+		return listLiteral(value, ct)
+	case CASS_VALUE_TYPE_SET:
+		return setLiteral(value, ct)
+	case CASS_VALUE_TYPE_MAP:
+		return mapLiteral(value, ct)
+	case CASS_VALUE_TYPE_TUPLE:
+		return tupleLiteral(value, ct)
+	case CASS_VALUE_TYPE_UDT:
+		return udtLiteral(value, ct)
+	case CASS_VALUE_TYPE_VARINT:
+		// bound as bytes
+		if v, ok := value.(*big.Int); ok {
+			return v.String(), nil
+		}
+	}
+
+	tv, err := newCassTypedVal(value, ct)
+	if err != nil {
+		return "", err
+	}
+	ptv, ok := tv.(*primitiveTypedVal)
+	if !ok {
+		tv.Free()
+		return "", fmt.Errorf("cannot convert %T into %s", value, ct.String())
+	}
+	return ptv.literal(), nil
+}
+
+// renders the converted value of a primitive type
+func (ptv primitiveTypedVal) literal() string {
+	switch ptv.kind.primary {
+	case CASS_VALUE_TYPE_ASCII, CASS_VALUE_TYPE_TEXT, CASS_VALUE_TYPE_VARCHAR:
+		return cqltext.QuoteString(ptv.val.(string))
+	case CASS_VALUE_TYPE_BOOLEAN:
+		return strconv.FormatBool(ptv.val.(int) != 0)
+	case CASS_VALUE_TYPE_FLOAT:
+		return floatLiteral(reflect.ValueOf(ptv.val).Float(), 32)
+	case CASS_VALUE_TYPE_DOUBLE:
+		return floatLiteral(reflect.ValueOf(ptv.val).Float(), 64)
+	case CASS_VALUE_TYPE_DECIMAL:
+		return ptv.val.(*Decimal).NativeString()
+	case CASS_VALUE_TYPE_UUID, CASS_VALUE_TYPE_TIMEUUID:
+		return ptv.val.(UUID).NativeString()
+	case CASS_VALUE_TYPE_TIMESTAMP:
+		return Timestamp{ptv.val.(int64)}.NativeString()
+	case CASS_VALUE_TYPE_DATE:
+		return Date{uint32(reflect.ValueOf(ptv.val).Uint())}.NativeString()
+	case CASS_VALUE_TYPE_TIME:
+		return Time(ptv.val.(int64)).NativeString()
+	case CASS_VALUE_TYPE_DURATION:
+		return ptv.val.(Duration).NativeString()
+	case CASS_VALUE_TYPE_INET:
+		return cqltext.QuoteString(net.IP(ptv.val.([]byte)).String())
+	case CASS_VALUE_TYPE_BLOB:
+		return "0x" + hex.EncodeToString(ptv.val.([]byte))
+	}
+	// integers
+	return fmt.Sprint(ptv.val)
+}
+
+// Floating point literals keep a decimal point or an exponent, so they
+// aren't mistaken for integers.
+func floatLiteral(f float64, bitSize int) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	}
+	s := strconv.FormatFloat(f, 'g', -1, bitSize)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+// the type of the elements of a collection, or CUnknown
+func subtype(ct CassType, index int) CassType {
+	if index < len(ct.subtypes) {
+		return ct.subtypes[index]
+	}
+	return CUnknown
+}
+
+func listLiteral(value interface{}, ct CassType) (string, error) {
+	rVal := reflect.ValueOf(value)
+	switch rVal.Kind() {
+	case reflect.Slice, reflect.Array:
+	default:
+		return "", fmt.Errorf("cannot convert %T into %s", value, ct.String())
+	}
+	elems := make([]string, rVal.Len())
+	for i := range elems {
+		elem, err := subtype(ct, 0).Literal(rVal.Index(i).Interface())
+		if err != nil {
+			return "", err
+		}
+		elems[i] = elem
+	}
+	return "[" + strings.Join(elems, ", ") + "]", nil
+}
+
+// The elements of sets and the entries of maps are sorted by their
+// literals, so that the literals of equal values are equal.
+func setLiteral(value interface{}, ct CassType) (string, error) {
+	if marker, ok := value.(setmarker); ok {
+		value = marker.value
+	}
+	var elems []reflect.Value
+	rVal := reflect.ValueOf(value)
+	switch rVal.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rVal.Len(); i++ {
+			elems = append(elems, rVal.Index(i))
+		}
+	case reflect.Map:
+		elems = rVal.MapKeys()
+	default:
+		return "", fmt.Errorf("cannot convert %T into %s", value, ct.String())
+	}
+	literals := make([]string, len(elems))
+	for i, elem := range elems {
+		literal, err := subtype(ct, 0).Literal(elem.Interface())
+		if err != nil {
+			return "", err
+		}
+		literals[i] = literal
+	}
+	sort.Strings(literals)
+	return "{" + strings.Join(literals, ", ") + "}", nil
+}
+
+func mapLiteral(value interface{}, ct CassType) (string, error) {
+	rVal := reflect.ValueOf(value)
+	if rVal.Kind() != reflect.Map {
+		return "", fmt.Errorf("cannot convert %T into %s", value, ct.String())
+	}
+	entries := make([]string, 0, rVal.Len())
+	for _, key := range rVal.MapKeys() {
+		k, err := subtype(ct, 0).Literal(key.Interface())
+		if err != nil {
+			return "", err
+		}
+		v, err := subtype(ct, 1).Literal(rVal.MapIndex(key).Interface())
+		if err != nil {
+			return "", err
+		}
+		entries = append(entries, k+": "+v)
+	}
+	sort.Strings(entries)
+	return "{" + strings.Join(entries, ", ") + "}", nil
+}
+
+func tupleLiteral(value interface{}, ct CassType) (string, error) {
+	var tuple *Tuple
+	switch value := value.(type) {
+	case *Tuple:
+		tuple = value
+	case Tuple:
+		tuple = &value
+	default:
+		return "", fmt.Errorf("cannot convert %T into %s", value, ct.String())
+	}
+	// the column metadata, when available, is more accurate than the
+	// types the tuple was created with
+	subtypes := ct.subtypes
+	if len(subtypes) == 0 {
+		subtypes = tuple.Kind().subtypes
+	}
+	if len(subtypes) == 0 {
+		// an untyped tuple, e.g. decoded from JSON
+		subtypes = make([]CassType, tuple.Len())
+		for i := range subtypes {
+			subtypes[i] = CUnknown
+		}
+	}
+	if tuple.Len() > len(subtypes) {
+		return "", fmt.Errorf("cannot convert a tuple with %d values into %s",
+			tuple.Len(), ct.String())
+	}
+	values := make([]string, len(subtypes))
+	for i, kind := range subtypes {
+		var v interface{}
+		if i < tuple.Len() {
+			v = tuple.Get(i)
+		}
+		literal, err := kind.Literal(v)
+		if err != nil {
+			return "", err
+		}
+		values[i] = literal
+	}
+	return "(" + strings.Join(values, ", ") + ")", nil
+}
+
+func udtLiteral(value interface{}, ct CassType) (string, error) {
+	rVal := reflect.ValueOf(value)
+	if rVal.Kind() != reflect.Map || rVal.Type().Key().Kind() != reflect.String {
+		return "", fmt.Errorf("cannot convert %T into %s", value, ct.String())
+	}
+	fields := make([]string, 0, rVal.Len())
+	for _, key := range rVal.MapKeys() {
+		name := key.String()
+		kind := CUnknown
+		for i, field := range ct.fieldNames {
+			if field == name {
+				kind = ct.subtypes[i]
+			}
+		}
+		v, err := kind.Literal(rVal.MapIndex(key).Interface())
+		if err != nil {
+			return "", err
+		}
+		fields = append(fields, cqltext.QuoteIdentifier(name)+": "+v)
+	}
+	sort.Strings(fields)
+	return "{" + strings.Join(fields, ", ") + "}", nil
+}
@@ -0,0 +1,167 @@
+package cassandra_test
+
+import (
+	"golang-driver/cassandra"
+	"golang-driver/cassandra/test"
+	"math"
+	"math/big"
+	"net"
+	"testing"
+)
+
+func TestLiteral(t *testing.T) {
+	u, _ := cassandra.ParseUUID("f0d07136-62f9-4d18-a6ce-cd5f4beb4348")
+	tm, _ := cassandra.NewTime(10, 15, 20, 5)
+	d, _ := cassandra.ParseDuration("1h30m")
+	address, err := cassandra.ParseCassType("ks.address(street text, zip int)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	varint, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	tests := []struct {
+		value    interface{}
+		kind     cassandra.CassType
+		expected string
+	}{
+		{nil, cassandra.CText, "null"},
+		{"it's", cassandra.CText, "'it''s'"},
+		{"abc", cassandra.CUnknown, "'abc'"},
+		{true, cassandra.CBoolean, "true"},
+		{42, cassandra.CInt, "42"},
+		{int64(-7), cassandra.CBigInt, "-7"},
+		{int8(3), cassandra.CTinyInt, "3"},
+		{1.5, cassandra.CDouble, "1.5"},
+		{float64(2), cassandra.CDouble, "2.0"},
+		{float32(0.1), cassandra.CFloat, "0.1"},
+		{1e21, cassandra.CDouble, "1e+21"},
+		{math.NaN(), cassandra.CDouble, "NaN"},
+		{math.Inf(-1), cassandra.CDouble, "-Infinity"},
+		{varint, cassandra.CVarint, "123456789012345678901234567890"},
+		{cassandra.NewDecimal(-12, 3), cassandra.CDecimal, "-0.012"},
+		{u, cassandra.CUuid, "f0d07136-62f9-4d18-a6ce-cd5f4beb4348"},
+		{[]byte{0xca, 0xfe}, cassandra.CBlob, "0xcafe"},
+		{[]byte{}, cassandra.CBlob, "0x"},
+		{net.ParseIP("10.0.0.1"), cassandra.CInet, "'10.0.0.1'"},
+		{mustDate(2016, 3, 1), cassandra.CDate, "'2016-03-01'"},
+		{tm, cassandra.CTime, "'10:15:20.000000005'"},
+		{cassandra.NewTimestamp(1450606299), cassandra.CTimestamp, "1450606299"},
+		{d, cassandra.CDuration, "1h30m"},
+		{[]int{1, 2}, cassandra.CUnknown, "[1, 2]"},
+		{[]string{"b", "a"}, cassandra.CSet.Specialize(cassandra.CText), "{'a', 'b'}"},
+		{cassandra.Set([]int{3, 1}), cassandra.CUnknown, "{1, 3}"},
+		{map[string]int{"b": 2, "a": 1}, cassandra.CUnknown, "{'a': 1, 'b': 2}"},
+		{map[int][]int{1: {2}, 3: nil},
+			cassandra.CMap.Specialize(cassandra.CInt, cassandra.CList.Specialize(cassandra.CInt)),
+			"{1: [2], 3: []}"},
+		{cassandra.NewTuple(cassandra.CTuple.Specialize(cassandra.CInt, cassandra.CText, cassandra.CBoolean), 1, "a"),
+			cassandra.CUnknown, "(1, 'a', null)"},
+		{map[string]interface{}{"zip": 1, "street": "Main St"}, address, "{street: 'Main St', zip: 1}"},
+		{cassandra.NullString{}, cassandra.CText, "null"},
+		{cassandra.NullString{String: "x", Valid: true}, cassandra.CText, "'x'"},
+		{Email{"joe", "example.com"}, cassandra.CText, "'joe@example.com'"},
+		{Email{}, cassandra.CText, "null"},
+	}
+	for _, test := range tests {
+		literal, err := test.kind.Literal(test.value)
+		if err != nil {
+			t.Errorf("%v (%s): %s", test.value, test.kind.String(), err)
+		} else if literal != test.expected {
+			t.Errorf("%v (%s): %s != %s (expected)", test.value, test.kind.String(),
+				literal, test.expected)
+		}
+	}
+
+	if _, err := cassandra.CInt.Literal("abc"); err == nil {
+		t.Error("expected an error converting a string into an int")
+	}
+	if _, err := cassandra.CMap.Literal([]int{1}); err == nil {
+		t.Error("expected an error converting a slice into a map")
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	if err := test.Setup(interpolateSetup); err != nil {
+		t.Log("Unexpected error while setup. You might need to clean up manually golang_driver keyspace")
+		t.Fatal(err)
+	}
+	defer test.TearDown(interpolateCleanup)
+
+	stmt, err := session.Query("INSERT INTO golang_driver.interpolated (id, name, tags) VALUES (?, ?, ?) -- ?",
+		1, "it's", cassandra.Set([]string{"b", "a"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+	cql, err := stmt.Interpolate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "INSERT INTO golang_driver.interpolated (id, name, tags) VALUES (1, 'it''s', {'a', 'b'}) -- ?"
+	if cql != expected {
+		t.Errorf("%s != %s (expected)", cql, expected)
+	}
+	if _, err := session.Exec(cql); err != nil {
+		t.Fatal(err)
+	}
+
+	// the column types of prepared statements are used, e.g. for sets
+	pstmt, err := session.Prepare("UPDATE golang_driver.interpolated SET name = :name, tags = :tags WHERE id = :id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pstmt.Close()
+	bound, err := pstmt.Query("?", []string{"b", "a"}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bound.Close()
+	if cql, err = bound.Interpolate(); err != nil {
+		t.Fatal(err)
+	}
+	expected = "UPDATE golang_driver.interpolated SET name = '?', tags = {'a', 'b'} WHERE id = 2"
+	if cql != expected {
+		t.Errorf("%s != %s (expected)", cql, expected)
+	}
+	if _, err := session.Exec(cql); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := session.Exec("SELECT name FROM golang_driver.interpolated WHERE id = ?", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var name string
+	if !rows.Next() {
+		t.Fatal("expected 1 row")
+	}
+	if err := rows.Scan(&name); err != nil {
+		t.Fatal(err)
+	} else if name != "?" {
+		t.Errorf("%s != ? (expected)", name)
+	}
+
+	unbound, err := session.Query("SELECT * FROM golang_driver.interpolated WHERE id = ?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unbound.Close()
+	if _, err := unbound.Interpolate(); err == nil {
+		t.Error("expected an error for a missing value")
+	}
+}
+
+var (
+	interpolateSetup = []string{
+		"CREATE KEYSPACE IF NOT EXISTS golang_driver WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};",
+		"CREATE TABLE IF NOT EXISTS golang_driver.interpolated (id int PRIMARY KEY, name text, tags set<text>)",
+	}
+
+	interpolateCleanup = []string{
+		"DROP TABLE golang_driver.interpolated",
+	}
+)
@@ -0,0 +1,428 @@
+// Package mapper generates and prepares the CQL statements reading and
+// writing the rows of a table as Go structs. The struct describes the
+// table with `cql` tags:
+//
+//	type Event struct {
+//		_       struct{}            `cql:"table=shop.events"`
+//		Account cassandra.UUID      `cql:"account,partition"`
+//		Day     cassandra.Date      `cql:"day,partition"`
+//		At      cassandra.Timestamp `cql:"at,clustering"`
+//		Kind    string
+//		Payload string `cql:"data"`
+//		Cache   string `cql:"-"`
+//	}
+//
+// The table is given by the tag of a blank field. The columns of the
+// partition key and of the clustering key are tagged with partition
+// and clustering, in the order of the key. The clustering columns in
+// descending order (WITH CLUSTERING ORDER BY (at DESC)) are tagged with
+// clustering,desc, e.g. `cql:"at,clustering,desc"`, so that the rows
+// are selected in the reverse of the table's order (see
+// Range.Descending). Like with cassandra.QueryOne,
+// the name of a column is the name in the tag of its field or the name
+// of the field in lower case, fields tagged with "-" are ignored and
+// the fields of embedded structs are promoted.
+//
+// Statements are prepared when they're first used and are kept until
+// the mapper is closed.
+package mapper
+
+import (
+	"errors"
+	"fmt"
+	"golang-driver/cassandra"
+	"golang-driver/cassandra/internal/cqltext"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+type column struct {
+	name  string
+	index []int
+	// a clustering column in descending order
+	desc bool
+}
+
+// Reads and writes the rows of a table as values of type T.
+type Mapper[T any] struct {
+	session    *cassandra.Session
+	table      string
+	partition  []column
+	clustering []column
+	regular    []column
+
+	mu       sync.Mutex
+	prepared map[string]*cassandra.PreparedStatement
+}
+
+// Options of the statements writing rows.
+type WriteOptions struct {
+	// the time to live of the written values (0 for no TTL), rounded up
+	// to a whole second
+	TTL time.Duration
+	// the write time of the values, in microseconds since Epoch (0 for
+	// the time the coordinator receives the statement)
+	Timestamp int64
+	// makes an insert conditional on the row not existing (IF NOT
+	// EXISTS), and an update or a delete on the row existing (IF EXISTS)
+	Conditional bool
+}
+
+// A range of the clustering key within a partition. From and To are
+// the values of the first clustering columns (possibly not all of
+// them) of the first and last rows: e.g. From: {day} selects the rows
+// from that day on and From: {day, hour} the rows from that hour of
+// that day on. Nil bounds aren't restricted.
+type Range struct {
+	From, To []interface{}
+	// excludes the rows equal to From or To
+	ExcludeFrom, ExcludeTo bool
+	// returns the rows in the reverse of the table's clustering order
+	Descending bool
+	// the maximum number of rows (0 for no limit)
+	Limit int
+}
+
+// Creates the mapper of the table described by T, which must be a
+// struct.
+func New[T any](session *cassandra.Session) (*Mapper[T], error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot map %s (not a struct)", t.String())
+	}
+	m := &Mapper[T]{session: session, prepared: make(map[string]*cassandra.PreparedStatement)}
+	if err := m.collect(t, nil); err != nil {
+		return nil, err
+	}
+	if m.table == "" {
+		return nil, fmt.Errorf("%s has no table (e.g. a field _ struct{} `cql:\"table=name\"`)", t.String())
+	}
+	if len(m.partition) == 0 {
+		return nil, fmt.Errorf("%s has no partition key column", t.String())
+	}
+	return m, nil
+}
+
+func (m *Mapper[T]) collect(t reflect.Type, parent []int) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("cql"), ",")
+		if field.Name == "_" {
+			if table, ok := strings.CutPrefix(name, "table="); ok {
+				m.table = cqltext.QuoteName(table)
+			}
+			continue
+		}
+		if name == "-" {
+			continue
+		}
+		index := append(append([]int{}, parent...), i)
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			if err := m.collect(field.Type, index); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		c := column{name: cqltext.QuoteIdentifier(name), index: index}
+		switch options {
+		case "partition":
+			m.partition = append(m.partition, c)
+		case "clustering":
+			m.clustering = append(m.clustering, c)
+		case "clustering,desc":
+			c.desc = true
+			m.clustering = append(m.clustering, c)
+		case "":
+			m.regular = append(m.regular, c)
+		default:
+			return fmt.Errorf("unknown option %q of %s.%s", options, t.String(), field.Name)
+		}
+	}
+	return nil
+}
+
+// Closes the prepared statements.
+func (m *Mapper[T]) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, pstmt := range m.prepared {
+		pstmt.Close()
+		delete(m.prepared, key)
+	}
+}
+
+// returns the statement prepared from the query
+func (m *Mapper[T]) prepare(query string) (*cassandra.PreparedStatement, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if pstmt, ok := m.prepared[query]; ok {
+		return pstmt, nil
+	}
+	pstmt, err := m.session.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	m.prepared[query] = pstmt
+	return pstmt, nil
+}
+
+func (m *Mapper[T]) primaryKey() []column {
+	return append(append([]column{}, m.partition...), m.clustering...)
+}
+
+func names(columns []column) []string {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.name
+	}
+	return names
+}
+
+// returns "a = ?<sep>b = ?" for the columns
+func equalities(columns []column, sep string) string {
+	eqs := make([]string, len(columns))
+	for i, c := range columns {
+		eqs[i] = c.name + " = ?"
+	}
+	return strings.Join(eqs, sep)
+}
+
+func conditions(columns []column) string {
+	return equalities(columns, " AND ")
+}
+
+func values(v reflect.Value, columns []column, args []interface{}) []interface{} {
+	for _, c := range columns {
+		args = append(args, v.FieldByIndex(c.index).Interface())
+	}
+	return args
+}
+
+// returns the USING clause and its values
+func using(opts WriteOptions, timestampOnly bool) (string, []interface{}, error) {
+	var clauses []string
+	var args []interface{}
+	if opts.TTL > 0 {
+		if timestampOnly {
+			return "", nil, errors.New("deletes cannot have a TTL")
+		}
+		clauses = append(clauses, "TTL ?")
+		// a TTL of 0 would mean no TTL
+		ttl := opts.TTL / time.Second
+		if opts.TTL%time.Second > 0 {
+			ttl++
+		}
+		args = append(args, int32(ttl))
+	}
+	if opts.Timestamp != 0 {
+		clauses = append(clauses, "TIMESTAMP ?")
+		args = append(args, opts.Timestamp)
+	}
+	if len(clauses) == 0 {
+		return "", nil, nil
+	}
+	return " USING " + strings.Join(clauses, " AND "), args, nil
+}
+
+// executes a write and returns whether it was applied
+func (m *Mapper[T]) exec(query string, args []interface{}, conditional bool) (bool, error) {
+	pstmt, err := m.prepare(query)
+	if err != nil {
+		return false, err
+	}
+	rows, err := pstmt.Exec(args...)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+	if !conditional {
+		return true, nil
+	}
+	// the first column of the result of a conditional statement is
+	// [applied]
+	if !rows.Next() {
+		return false, errors.New("no result for a conditional statement")
+	}
+	var applied bool
+	if err := rows.Scan(&applied); err != nil {
+		return false, err
+	}
+	return applied, nil
+}
+
+// Inserts (or overwrites) the row.
+func (m *Mapper[T]) Insert(v T) error {
+	_, err := m.InsertWithOptions(v, WriteOptions{})
+	return err
+}
+
+// Inserts the row with the given options. Returns false if the insert
+// is conditional and the row already exists.
+func (m *Mapper[T]) InsertWithOptions(v T, opts WriteOptions) (bool, error) {
+	columns := append(m.primaryKey(), m.regular...)
+	markers := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		m.table, strings.Join(names(columns), ", "), markers)
+	if opts.Conditional {
+		query += " IF NOT EXISTS"
+	}
+	clause, usingArgs, err := using(opts, false)
+	if err != nil {
+		return false, err
+	}
+	query += clause
+	args := values(reflect.ValueOf(v), columns, nil)
+	return m.exec(query, append(args, usingArgs...), opts.Conditional)
+}
+
+// Updates the regular columns of the row (which is created if it
+// doesn't exist).
+func (m *Mapper[T]) Update(v T) error {
+	_, err := m.UpdateWithOptions(v, WriteOptions{})
+	return err
+}
+
+// Updates the row with the given options. Returns false if the update
+// is conditional and the row doesn't exist.
+func (m *Mapper[T]) UpdateWithOptions(v T, opts WriteOptions) (bool, error) {
+	if len(m.regular) == 0 {
+		return false, fmt.Errorf("%s has no column to update", m.table)
+	}
+	clause, args, err := using(opts, false)
+	if err != nil {
+		return false, err
+	}
+	query := fmt.Sprintf("UPDATE %s%s SET %s WHERE %s", m.table, clause,
+		equalities(m.regular, ", "),
+		conditions(m.primaryKey()))
+	if opts.Conditional {
+		query += " IF EXISTS"
+	}
+	rVal := reflect.ValueOf(v)
+	args = values(rVal, m.regular, args)
+	args = values(rVal, m.primaryKey(), args)
+	return m.exec(query, args, opts.Conditional)
+}
+
+// Deletes the row with the primary key of v.
+func (m *Mapper[T]) Delete(v T) error {
+	_, err := m.DeleteWithOptions(v, WriteOptions{})
+	return err
+}
+
+// Deletes the row with the given options, which cannot have a TTL.
+// Returns false if the delete is conditional and the row doesn't
+// exist.
+func (m *Mapper[T]) DeleteWithOptions(v T, opts WriteOptions) (bool, error) {
+	clause, args, err := using(opts, true)
+	if err != nil {
+		return false, err
+	}
+	query := fmt.Sprintf("DELETE FROM %s%s WHERE %s", m.table, clause, conditions(m.primaryKey()))
+	if opts.Conditional {
+		query += " IF EXISTS"
+	}
+	args = values(reflect.ValueOf(v), m.primaryKey(), args)
+	return m.exec(query, args, opts.Conditional)
+}
+
+func (m *Mapper[T]) selectColumns() string {
+	return strings.Join(names(append(m.primaryKey(), m.regular...)), ", ")
+}
+
+// Returns the row with the given primary key (the values of the
+// partition key columns followed by the values of the clustering
+// columns), or cassandra.ErrNoRows if there's none.
+func (m *Mapper[T]) Get(key ...interface{}) (T, error) {
+	var zero T
+	if len(key) != len(m.partition)+len(m.clustering) {
+		return zero, fmt.Errorf("expected %d key values, got %d",
+			len(m.partition)+len(m.clustering), len(key))
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s", m.selectColumns(), m.table,
+		conditions(m.primaryKey()))
+	pstmt, err := m.prepare(query)
+	if err != nil {
+		return zero, err
+	}
+	rows, err := pstmt.Exec(key...)
+	if err != nil {
+		return zero, err
+	}
+	defer rows.Close()
+	return cassandra.ScanOne[T](rows)
+}
+
+// Returns the rows of the partition with the given key (the values of
+// the partition key columns) within the given clustering range.
+func (m *Mapper[T]) Select(partitionKey []interface{}, r Range) ([]T, error) {
+	if len(partitionKey) != len(m.partition) {
+		return nil, fmt.Errorf("expected %d partition key values, got %d",
+			len(m.partition), len(partitionKey))
+	}
+	if len(r.From) > len(m.clustering) || len(r.To) > len(m.clustering) {
+		return nil, fmt.Errorf("%s has only %d clustering columns", m.table, len(m.clustering))
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s", m.selectColumns(), m.table,
+		conditions(m.partition))
+	args := append([]interface{}{}, partitionKey...)
+	if len(r.From) > 0 {
+		op := ">="
+		if r.ExcludeFrom {
+			op = ">"
+		}
+		query += " AND " + m.slice(len(r.From), op)
+		args = append(args, r.From...)
+	}
+	if len(r.To) > 0 {
+		op := "<="
+		if r.ExcludeTo {
+			op = "<"
+		}
+		query += " AND " + m.slice(len(r.To), op)
+		args = append(args, r.To...)
+	}
+	if r.Descending {
+		if len(m.clustering) == 0 {
+			return nil, fmt.Errorf("%s has no clustering column to order by", m.table)
+		}
+		order := make([]string, len(m.clustering))
+		for i, c := range m.clustering {
+			if c.desc {
+				order[i] = c.name + " ASC"
+			} else {
+				order[i] = c.name + " DESC"
+			}
+		}
+		query += " ORDER BY " + strings.Join(order, ", ")
+	}
+	if r.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, int32(r.Limit))
+	}
+	pstmt, err := m.prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := pstmt.Exec(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return cassandra.ScanAll[T](rows)
+}
+
+// returns the restriction of the first n clustering columns, e.g.
+// (a, b) >= (?, ?)
+func (m *Mapper[T]) slice(n int, op string) string {
+	markers := strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+	return fmt.Sprintf("(%s) %s (%s)", strings.Join(names(m.clustering[:n]), ", "), op, markers)
+}
@@ -0,0 +1,265 @@
+package mapper_test
+
+import (
+	"golang-driver/cassandra"
+	"golang-driver/cassandra/mapper"
+	"golang-driver/cassandra/test"
+	"testing"
+	"time"
+)
+
+type reading struct {
+	_       struct{}       `cql:"table=golang_driver.readings"`
+	Sensor  string         `cql:"sensor,partition"`
+	Day     cassandra.Date `cql:"day,partition"`
+	Hour    int32          `cql:"hour,clustering"`
+	Minute  int32          `cql:"minute,clustering"`
+	Value   float64
+	Comment cassandra.NullString `cql:"note"`
+	Cached  string               `cql:"-"`
+}
+
+type logEntry struct {
+	_       struct{} `cql:"table=golang_driver.log_entries"`
+	Stream  string   `cql:"stream,partition"`
+	At      int32    `cql:"at,clustering,desc"`
+	Seq     int32    `cql:"seq,clustering"`
+	Message string
+}
+
+func TestInvalidMappings(t *testing.T) {
+	type noTable struct {
+		ID int32 `cql:"id,partition"`
+	}
+	if _, err := mapper.New[noTable](nil); err == nil {
+		t.Error("a struct without table should be an error")
+	}
+	type noKey struct {
+		_  struct{} `cql:"table=t"`
+		ID int32
+	}
+	if _, err := mapper.New[noKey](nil); err == nil {
+		t.Error("a struct without partition key should be an error")
+	}
+	type badOption struct {
+		_  struct{} `cql:"table=t"`
+		ID int32    `cql:"id,primary"`
+	}
+	if _, err := mapper.New[badOption](nil); err == nil {
+		t.Error("an unknown option should be an error")
+	}
+	type descPartition struct {
+		_  struct{} `cql:"table=t"`
+		ID int32    `cql:"id,partition,desc"`
+	}
+	if _, err := mapper.New[descPartition](nil); err == nil {
+		t.Error("a descending partition key column should be an error")
+	}
+	if _, err := mapper.New[int](nil); err == nil {
+		t.Error("a non-struct should be an error")
+	}
+	if _, err := mapper.New[reading](nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMapper(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	if err := test.Setup(mapperSetup); err != nil {
+		t.Log("Unexpected error while setup. You might need to clean up manually golang_driver keyspace")
+		t.Fatal(err)
+	}
+	defer test.TearDown(mapperCleanup)
+
+	m, err := mapper.New[reading](session)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	day, err := cassandra.NewDate(2016, 3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for hour := int32(0); hour < 3; hour++ {
+		for minute := int32(0); minute < 60; minute += 30 {
+			r := reading{Sensor: "s1", Day: day, Hour: hour, Minute: minute, Value: float64(hour*60 + minute)}
+			if err := m.Insert(r); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	r, err := m.Get("s1", day, int32(1), int32(30))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Value != 90 || r.Comment.Valid {
+		t.Errorf("unexpected %+v", r)
+	}
+	if _, err := m.Get("s1", day, int32(5), int32(0)); err != cassandra.ErrNoRows {
+		t.Errorf("expected ErrNoRows, got %v", err)
+	}
+
+	// conditional writes
+	applied, err := m.InsertWithOptions(r, mapper.WriteOptions{Conditional: true})
+	if err != nil || applied {
+		t.Errorf("the insert should not be applied (%v)", err)
+	}
+	r.Comment = cassandra.NullString{String: "checked", Valid: true}
+	r.Value = 91
+	if applied, err := m.UpdateWithOptions(r, mapper.WriteOptions{Conditional: true}); err != nil || !applied {
+		t.Errorf("the update should be applied (%v)", err)
+	}
+	if r, err := m.Get("s1", day, int32(1), int32(30)); err != nil || r.Value != 91 || r.Comment.String != "checked" {
+		t.Errorf("unexpected %+v (%v)", r, err)
+	}
+	ghost := reading{Sensor: "s2", Day: day}
+	if applied, err := m.UpdateWithOptions(ghost, mapper.WriteOptions{Conditional: true}); err != nil || applied {
+		t.Errorf("the update of a missing row should not be applied (%v)", err)
+	}
+
+	// TTL and write time
+	expiring := reading{Sensor: "s1", Day: day, Hour: 23, Minute: 59, Value: 1}
+	if _, err := m.InsertWithOptions(expiring, mapper.WriteOptions{TTL: time.Hour, Timestamp: 1456790400000000}); err != nil {
+		t.Fatal(err)
+	}
+	var ttl int32
+	var writetime int64
+	rows, err := session.Exec("SELECT TTL(value), WRITETIME(value) FROM golang_driver.readings WHERE sensor = 's1' AND day = ? AND hour = 23 AND minute = 59", day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rows.Next() {
+		t.Fatal("expected a row")
+	}
+	if err := rows.Scan(&ttl, &writetime); err != nil {
+		t.Fatal(err)
+	}
+	rows.Close()
+	if ttl <= 0 || ttl > 3600 || writetime != 1456790400000000 {
+		t.Errorf("unexpected TTL %d and write time %d", ttl, writetime)
+	}
+	if _, err := m.DeleteWithOptions(expiring, mapper.WriteOptions{TTL: time.Hour}); err == nil {
+		t.Error("a delete with a TTL should be an error")
+	}
+	if err := m.Delete(expiring); err != nil {
+		t.Fatal(err)
+	}
+
+	// sub-second TTLs are rounded up rather than dropped
+	if _, err := m.InsertWithOptions(expiring, mapper.WriteOptions{TTL: 500 * time.Millisecond}); err != nil {
+		t.Fatal(err)
+	}
+	var subSecondTTL cassandra.NullInt32
+	rows, err = session.Exec("SELECT TTL(value) FROM golang_driver.readings WHERE sensor = 's1' AND day = ? AND hour = 23 AND minute = 59", day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows.Next() {
+		if err := rows.Scan(&subSecondTTL); err != nil {
+			t.Fatal(err)
+		}
+		if !subSecondTTL.Valid || subSecondTTL.Int32 != 1 {
+			t.Errorf("expected a TTL of 1s, got %+v", subSecondTTL)
+		}
+	}
+	rows.Close()
+
+	// clustering ranges
+	readings, err := m.Select([]interface{}{"s1", day}, mapper.Range{
+		From: []interface{}{int32(0), int32(30)},
+		To:   []interface{}{int32(2)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(readings) != 5 || readings[0].Hour != 0 || readings[0].Minute != 30 || readings[4].Hour != 2 {
+		t.Errorf("unexpected readings %+v", readings)
+	}
+	readings, err = m.Select([]interface{}{"s1", day}, mapper.Range{
+		To: []interface{}{int32(2)}, ExcludeTo: true, Descending: true, Limit: 3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(readings) != 3 || readings[0].Hour != 1 || readings[0].Minute != 30 || readings[2].Hour != 0 {
+		t.Errorf("unexpected readings %+v", readings)
+	}
+	if _, err := m.Select([]interface{}{"s1"}, mapper.Range{}); err == nil {
+		t.Error("an incomplete partition key should be an error")
+	}
+}
+
+func TestMapperClusteringOrder(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	if err := test.Setup(mapperOrderSetup); err != nil {
+		t.Log("Unexpected error while setup. You might need to clean up manually golang_driver keyspace")
+		t.Fatal(err)
+	}
+	defer test.TearDown(mapperOrderCleanup)
+
+	m, err := mapper.New[logEntry](session)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	for at := int32(1); at <= 2; at++ {
+		for seq := int32(1); seq <= 2; seq++ {
+			if err := m.Insert(logEntry{Stream: "app", At: at, Seq: seq, Message: "started"}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// the table's order is at DESC, seq ASC
+	tests := []struct {
+		descending bool
+		expected   [][2]int32
+	}{
+		{false, [][2]int32{{2, 1}, {2, 2}, {1, 1}, {1, 2}}},
+		{true, [][2]int32{{1, 2}, {1, 1}, {2, 2}, {2, 1}}},
+	}
+	for _, test := range tests {
+		entries, err := m.Select([]interface{}{"app"}, mapper.Range{Descending: test.descending})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != len(test.expected) {
+			t.Fatalf("expected %d entries, got %+v", len(test.expected), entries)
+		}
+		for i, e := range entries {
+			if [2]int32{e.At, e.Seq} != test.expected[i] {
+				t.Errorf("descending %t: unexpected entries %+v", test.descending, entries)
+				break
+			}
+		}
+	}
+}
+
+var (
+	mapperSetup = []string{
+		"CREATE KEYSPACE IF NOT EXISTS golang_driver WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};",
+		`CREATE TABLE IF NOT EXISTS golang_driver.readings(sensor text, day date, hour int, minute int, value double, note text,
+		PRIMARY KEY ((sensor, day), hour, minute))`,
+	}
+
+	mapperCleanup = []string{
+		"DROP TABLE golang_driver.readings",
+	}
+
+	mapperOrderSetup = []string{
+		"CREATE KEYSPACE IF NOT EXISTS golang_driver WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};",
+		`CREATE TABLE IF NOT EXISTS golang_driver.log_entries(stream text, at int, seq int, message text,
+		PRIMARY KEY (stream, at, seq)) WITH CLUSTERING ORDER BY (at DESC, seq ASC)`,
+	}
+
+	mapperOrderCleanup = []string{
+		"DROP TABLE golang_driver.log_entries",
+	}
+)
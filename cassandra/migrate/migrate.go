@@ -0,0 +1,460 @@
+// Package migrate applies versioned CQL scripts to a cluster, e.g.
+//
+//	migrations, err := migrate.Load(os.DirFS("migrations"))
+//	if err != nil {
+//		return err
+//	}
+//	m, err := migrate.New(session, migrate.Options{Keyspace: "shop"})
+//	if err != nil {
+//		return err
+//	}
+//	applied, err := m.Up(migrations)
+//
+// The applied migrations are recorded, with the checksums of their
+// scripts, in a tracking table of the keyspace given in the options.
+// The migrations are applied in the order of their versions, and the
+// statements of a script in order; the runs wait for the nodes to agree
+// on the schema after each schema change. Concurrent runs (e.g. by the
+// instances of a service starting together) are serialized by a lock
+// taken with a lightweight transaction. The tracking tables are read
+// and written at QUORUM, and the lock at SERIAL, unless the options say
+// otherwise.
+//
+// Cassandra has no transactional DDL: a migration failing in the middle
+// of its script isn't recorded, and is applied again from its first
+// statement by the next run. The statements of the scripts should then
+// be idempotent, e.g. CREATE TABLE IF NOT EXISTS.
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"golang-driver/cassandra"
+	"golang-driver/cassandra/qb"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Options of a Migrator.
+type Options struct {
+	// the keyspace of the tracking tables, which must exist
+	Keyspace string
+	// the table recording the applied migrations (schema_migrations by
+	// default); the lock is held in the table of the same name with a
+	// _lock suffix
+	Table string
+	// prints the statements of the pending migrations (and the ones
+	// recording them) to Out instead of executing them
+	DryRun bool
+	// where the script of a dry run, and the progress of a run, are
+	// written (nil to discard them)
+	Out io.Writer
+	// how long to wait for the lock held by a concurrent run (1 minute
+	// by default)
+	LockTimeout time.Duration
+	// how long the lock is kept by a run that doesn't release it, e.g.
+	// because it crashed (10 minutes by default); it's renewed before
+	// each migration
+	LockTTL time.Duration
+	// how long to wait for the nodes to agree on the schema after a
+	// schema change (30 seconds by default)
+	SchemaAgreementTimeout time.Duration
+	// the consistency of the reads and writes of the tracking tables
+	// (QUORUM by default, e.g. LOCAL_QUORUM for a cluster spanning
+	// several datacenters)
+	Consistency cassandra.Consistency
+	// the serial consistency of the lock (SERIAL by default, e.g.
+	// LOCAL_SERIAL with LOCAL_QUORUM)
+	SerialConsistency cassandra.Consistency
+}
+
+// An applied migration, as recorded in the tracking table.
+type Applied struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Applies migrations with a session.
+type Migrator struct {
+	session   *cassandra.Session
+	opts      Options
+	table     string
+	lockTable string
+	// identifies the run in the lock table
+	owner string
+}
+
+const (
+	lockID               = "migrations"
+	lockPollInterval     = time.Second
+	schemaPollInterval   = 200 * time.Millisecond
+	defaultTable         = "schema_migrations"
+	defaultLockTimeout   = time.Minute
+	defaultLockTTL       = 10 * time.Minute
+	defaultSchemaTimeout = 30 * time.Second
+)
+
+// Creates a migrator recording the migrations applied with the session
+// in the keyspace of the options.
+func New(session *cassandra.Session, opts Options) (*Migrator, error) {
+	if opts.Keyspace == "" {
+		return nil, errors.New("the keyspace of the tracking table is required")
+	}
+	if opts.Table == "" {
+		opts.Table = defaultTable
+	}
+	if opts.Out == nil {
+		opts.Out = io.Discard
+	}
+	if opts.LockTimeout <= 0 {
+		opts.LockTimeout = defaultLockTimeout
+	}
+	if opts.LockTTL <= 0 {
+		opts.LockTTL = defaultLockTTL
+	}
+	if opts.SchemaAgreementTimeout <= 0 {
+		opts.SchemaAgreementTimeout = defaultSchemaTimeout
+	}
+	if opts.Consistency == 0 {
+		opts.Consistency = cassandra.QUORUM
+	}
+	if opts.SerialConsistency == 0 {
+		opts.SerialConsistency = cassandra.SERIAL
+	}
+	host, _ := os.Hostname()
+	return &Migrator{
+		session:   session,
+		opts:      opts,
+		table:     opts.Keyspace + "." + opts.Table,
+		lockTable: opts.Keyspace + "." + opts.Table + "_lock",
+		owner:     host + ":" + strconv.Itoa(os.Getpid()) + ":" + cassandra.NewRandomUUID().String(),
+	}, nil
+}
+
+// Applies the migrations which aren't applied yet, and returns them (or
+// the ones a dry run would apply). It fails without applying anything
+// if an applied migration was changed, or if a migration that isn't
+// applied is older than the last applied one.
+func (m *Migrator) Up(migrations []Migration) ([]Migration, error) {
+	migrations, err := sorted(migrations)
+	if err != nil {
+		return nil, err
+	}
+	if !m.opts.DryRun {
+		if err := m.createTables(); err != nil {
+			return nil, err
+		}
+		if err := m.lock(); err != nil {
+			return nil, err
+		}
+		defer m.unlock()
+	}
+
+	applied, err := m.Applied()
+	if err != nil {
+		return nil, err
+	}
+	pending, err := Pending(migrations, applied)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, migration := range pending {
+		if m.opts.DryRun {
+			err = m.print(migration)
+		} else {
+			err = m.apply(migration)
+		}
+		if err != nil {
+			return pending[:i], err
+		}
+	}
+	return pending, nil
+}
+
+// Returns the migrations which aren't applied, or an error if an
+// applied migration was changed or if a migration which isn't applied
+// is older than the last applied one.
+func Pending(migrations []Migration, applied []Applied) ([]Migration, error) {
+	checksums := make(map[int64]string, len(applied))
+	var last int64 = -1
+	for _, a := range applied {
+		checksums[a.Version] = a.Checksum
+		if a.Version > last {
+			last = a.Version
+		}
+	}
+	var pending []Migration
+	for _, migration := range migrations {
+		checksum, ok := checksums[migration.Version]
+		switch {
+		case ok && checksum != migration.Checksum:
+			return nil, fmt.Errorf("migration %s was changed after it was applied", migration.String())
+		case ok:
+		case migration.Version < last:
+			return nil, fmt.Errorf("migration %s is older than the last applied migration %d",
+				migration.String(), last)
+		default:
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// Returns the applied migrations, in the order of their versions. There
+// are none if the tracking table doesn't exist yet.
+func (m *Migrator) Applied() ([]Applied, error) {
+	exists, err := m.tableExists()
+	if err != nil || !exists {
+		return nil, err
+	}
+	query, args := qb.Select(m.table, "version", "name", "checksum", "applied_at").Build()
+	rows, err := m.query(m.opts.Consistency, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var applied []Applied
+	for rows.Next() {
+		var a Applied
+		var at cassandra.UUID
+		if err := rows.Scan(&a.Version, &a.Name, &a.Checksum, &at); err != nil {
+			return nil, err
+		}
+		a.AppliedAt = at.Time()
+		applied = append(applied, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(applied, func(i, j int) bool { return applied[i].Version < applied[j].Version })
+	return applied, nil
+}
+
+func (m *Migrator) tableExists() (bool, error) {
+	rows, err := m.session.Exec("SELECT table_name FROM system_schema.tables WHERE keyspace_name = ? AND table_name = ?",
+		m.opts.Keyspace, m.opts.Table)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+	exists := rows.Next()
+	return exists, rows.Err()
+}
+
+func (m *Migrator) createTables() error {
+	for _, b := range []qb.Builder{
+		qb.CreateTable(m.table).IfNotExists().
+			Column("version", "bigint").Column("name", "text").
+			Column("checksum", "text").Column("applied_at", "timeuuid").
+			PartitionKey("version"),
+		qb.CreateTable(m.lockTable).IfNotExists().
+			Column("id", "text").Column("owner", "text").
+			PartitionKey("id"),
+	} {
+		query, _ := b.Build()
+		if err := m.exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// the statement recording the migration
+func record(table string, migration Migration) (string, []interface{}) {
+	return qb.Insert(table).
+		Value("version", migration.Version).
+		Value("name", migration.Name).
+		Value("checksum", migration.Checksum).
+		Value("applied_at", cassandra.NewTimeUUID()).
+		Build()
+}
+
+func (m *Migrator) apply(migration Migration) error {
+	if err := m.renewLock(); err != nil {
+		return err
+	}
+	fmt.Fprintf(m.opts.Out, "applying %s\n", migration.String())
+	for i, statement := range migration.Statements() {
+		if err := m.exec(statement); err != nil {
+			return fmt.Errorf("migration %s, statement %d: %s", migration.String(), i+1, err.Error())
+		}
+	}
+	query, args := record(m.table, migration)
+	rows, err := m.query(m.opts.Consistency, query, args...)
+	if err != nil {
+		return err
+	}
+	rows.Close()
+	return nil
+}
+
+// executes a statement on the tracking tables at the given consistency
+func (m *Migrator) query(consistency cassandra.Consistency, query string, args ...interface{}) (*cassandra.Rows, error) {
+	stmt, err := m.session.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	return stmt.WithConsistency(consistency).
+		WithSerialConsistency(m.opts.SerialConsistency).
+		Exec()
+}
+
+// executes a statement of a migration, and waits for the schema
+// agreement if it changed the schema
+func (m *Migrator) exec(statement string) error {
+	rows, err := m.session.Exec(statement)
+	if err != nil {
+		return err
+	}
+	rows.Close()
+	switch keyword(statement) {
+	case "CREATE", "ALTER", "DROP":
+		return m.waitForSchemaAgreement()
+	}
+	return nil
+}
+
+// prints the statements of the migration, followed by the one recording
+// it
+func (m *Migrator) print(migration Migration) error {
+	fmt.Fprintf(m.opts.Out, "-- %s (sha256 %s)\n", migration.String(), migration.Checksum)
+	for _, statement := range migration.Statements() {
+		fmt.Fprintf(m.opts.Out, "%s;\n", statement)
+	}
+	query, args := record(m.table, migration)
+	stmt, err := m.session.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	text, err := stmt.Interpolate()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(m.opts.Out, "%s;\n\n", text)
+	return nil
+}
+
+// Waits until the nodes which are up report the same schema version.
+func (m *Migrator) waitForSchemaAgreement() error {
+	deadline := time.Now().Add(m.opts.SchemaAgreementTimeout)
+	for {
+		agreed, err := m.schemaAgreed()
+		if err != nil || agreed {
+			return err
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("the nodes didn't agree on the schema within %s",
+				m.opts.SchemaAgreementTimeout)
+		}
+		time.Sleep(schemaPollInterval)
+	}
+}
+
+func (m *Migrator) schemaAgreed() (bool, error) {
+	versions := make(map[cassandra.UUID]bool)
+	for _, query := range []string{
+		"SELECT schema_version FROM system.local",
+		"SELECT schema_version FROM system.peers",
+	} {
+		rows, err := m.session.Exec(query)
+		if err != nil {
+			return false, err
+		}
+		for rows.Next() {
+			// the peers which are down have no schema version
+			var version cassandra.NullUUID
+			if err := rows.Scan(&version); err != nil {
+				rows.Close()
+				return false, err
+			}
+			if version.Valid {
+				versions[version.UUID] = true
+			}
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return false, err
+		}
+	}
+	return len(versions) <= 1, nil
+}
+
+// Takes the lock, waiting for a concurrent run to release it.
+func (m *Migrator) lock() error {
+	deadline := time.Now().Add(m.opts.LockTimeout)
+	for {
+		locked, err := m.conditional(qb.Insert(m.lockTable).
+			Value("id", lockID).Value("owner", m.owner).
+			IfNotExists().TTL(m.opts.LockTTL))
+		if err != nil || locked {
+			return err
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("the migrations are locked by %s", m.lockOwner())
+		}
+		time.Sleep(lockPollInterval)
+	}
+}
+
+// keeps the lock for another LockTTL
+func (m *Migrator) renewLock() error {
+	renewed, err := m.conditional(qb.Update(m.lockTable).TTL(m.opts.LockTTL).
+		Set("owner", m.owner).
+		Where(qb.Eq("id", lockID)).
+		If(qb.Eq("owner", m.owner)))
+	if err == nil && !renewed {
+		err = fmt.Errorf("the lock expired and is held by %s", m.lockOwner())
+	}
+	return err
+}
+
+func (m *Migrator) unlock() {
+	m.conditional(qb.Delete(m.lockTable).
+		Where(qb.Eq("id", lockID)).
+		If(qb.Eq("owner", m.owner)))
+}
+
+// returns the owner of the lock, for error messages; the lock row is
+// read at the serial consistency, so that the owner of a lock taken by
+// a transaction in progress is seen
+func (m *Migrator) lockOwner() string {
+	query, args := qb.Select(m.lockTable, "owner").Where(qb.Eq("id", lockID)).Build()
+	rows, err := m.query(m.opts.SerialConsistency, query, args...)
+	if err != nil {
+		return "another run"
+	}
+	defer rows.Close()
+	var owner string
+	if !rows.Next() || rows.Scan(&owner) != nil {
+		return "another run"
+	}
+	return owner
+}
+
+// executes a conditional statement and returns whether it was applied
+func (m *Migrator) conditional(b qb.Builder) (bool, error) {
+	query, args := b.Build()
+	rows, err := m.query(m.opts.Consistency, query, args...)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+	// the first column of the result is [applied]
+	if !rows.Next() {
+		return false, errors.New("no result for a conditional statement")
+	}
+	var applied bool
+	if err := rows.Scan(&applied); err != nil {
+		return false, err
+	}
+	return applied, nil
+}
@@ -0,0 +1,194 @@
+package migrate_test
+
+import (
+	"bytes"
+	"golang-driver/cassandra/migrate"
+	"golang-driver/cassandra/test"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestSplit(t *testing.T) {
+	script := `-- the users
+CREATE TABLE users (id int PRIMARY KEY, name text);
+INSERT INTO users (id, name) VALUES (1, 'a;b''c'); // one; two
+/* a comment; */ INSERT INTO "odd;" (id) VALUES (2);
+CREATE FUNCTION f(x int) RETURNS NULL ON NULL INPUT RETURNS int
+    LANGUAGE java AS $$ return x; $$;
+BEGIN BATCH
+  INSERT INTO users (id) VALUES (3);
+  INSERT INTO users (id) VALUES (4);
+APPLY BATCH;
+;
+-- the end; really
+`
+	expected := []string{
+		"-- the users\nCREATE TABLE users (id int PRIMARY KEY, name text)",
+		"INSERT INTO users (id, name) VALUES (1, 'a;b''c')",
+		"// one; two\n/* a comment; */ INSERT INTO \"odd;\" (id) VALUES (2)",
+		"CREATE FUNCTION f(x int) RETURNS NULL ON NULL INPUT RETURNS int\n    LANGUAGE java AS $$ return x; $$",
+		"BEGIN BATCH\n  INSERT INTO users (id) VALUES (3);\n  INSERT INTO users (id) VALUES (4);\nAPPLY BATCH",
+	}
+	actual := migrate.Split(script)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected\n%q\ngot\n%q", expected, actual)
+	}
+	if statements := migrate.Split("SELECT * FROM t"); len(statements) != 1 {
+		t.Errorf("expected the unterminated statement, got %q", statements)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := migrate.Load(fstest.MapFS{
+		"010_add_index.cql":   {Data: []byte("CREATE INDEX ON users (name);")},
+		"2_create_users.cql":  {Data: []byte("CREATE TABLE users (id int PRIMARY KEY);")},
+		"README.md":           {Data: []byte("ignored")},
+		"old/1_ignored.cql":   {Data: []byte("ignored")},
+		"3.cql":               {Data: []byte("")},
+		"4_drop_old_data.txt": {Data: []byte("ignored")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, m := range migrations {
+		names = append(names, m.String())
+	}
+	if expected := []string{"2 create_users", "3", "10 add_index"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("%v != %v (expected)", names, expected)
+	}
+	if migrations[1].Checksum != "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" {
+		t.Errorf("unexpected checksum %s of an empty script", migrations[1].Checksum)
+	}
+
+	if _, err := migrate.Load(fstest.MapFS{"create_users.cql": {}}); err == nil {
+		t.Error("expected an error for a file without version")
+	}
+	if _, err := migrate.Load(fstest.MapFS{"1_a.cql": {}, "01_b.cql": {}}); err == nil {
+		t.Error("expected an error for duplicate versions")
+	}
+}
+
+func TestPending(t *testing.T) {
+	m1 := migrate.NewMigration(1, "a", "CREATE TABLE a (id int PRIMARY KEY);")
+	m2 := migrate.NewMigration(2, "b", "CREATE TABLE b (id int PRIMARY KEY);")
+	m3 := migrate.NewMigration(3, "c", "CREATE TABLE c (id int PRIMARY KEY);")
+	applied := []migrate.Applied{{Version: 2, Name: "b", Checksum: m2.Checksum}}
+
+	pending, err := migrate.Pending([]migrate.Migration{m2, m3}, applied)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].Version != 3 {
+		t.Errorf("expected the migration 3 to be pending, got %v", pending)
+	}
+	if _, err := migrate.Pending([]migrate.Migration{m1, m2, m3}, applied); err == nil {
+		t.Error("expected an error for a migration older than the applied ones")
+	}
+	changed := migrate.NewMigration(2, "b", "CREATE TABLE b (id text PRIMARY KEY);")
+	if _, err := migrate.Pending([]migrate.Migration{changed}, applied); err == nil {
+		t.Error("expected an error for a changed migration")
+	}
+}
+
+func TestUp(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	if err := test.Setup(migrateSetup); err != nil {
+		t.Log("Unexpected error while setup. You might need to clean up manually golang_driver keyspace")
+		t.Fatal(err)
+	}
+	defer test.TearDown(migrateCleanup)
+
+	migrations := []migrate.Migration{
+		migrate.NewMigration(1, "create_accounts",
+			"CREATE TABLE IF NOT EXISTS golang_driver.accounts (id int PRIMARY KEY, name text);\n"+
+				"INSERT INTO golang_driver.accounts (id, name) VALUES (1, 'it''s; me');"),
+		migrate.NewMigration(2, "add_email",
+			"ALTER TABLE golang_driver.accounts ADD email text;"),
+	}
+
+	var out bytes.Buffer
+	dryRun, err := migrate.New(session, migrate.Options{Keyspace: "golang_driver", DryRun: true, Out: &out})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pending, err := dryRun.Up(migrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 2 {
+		t.Errorf("expected 2 pending migrations, got %d", len(pending))
+	}
+	script := out.String()
+	for _, expected := range []string{
+		"-- 1 create_accounts",
+		"INSERT INTO golang_driver.accounts (id, name) VALUES (1, 'it''s; me');",
+		"ALTER TABLE golang_driver.accounts ADD email text;",
+		"INSERT INTO golang_driver.schema_migrations (version, name, checksum, applied_at) VALUES (2, 'add_email', '" +
+			migrations[1].Checksum + "', ",
+	} {
+		if !strings.Contains(script, expected) {
+			t.Errorf("expected %q in the dry run script\n%s", expected, script)
+		}
+	}
+
+	m, err := migrate.New(session, migrate.Options{Keyspace: "golang_driver"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	applied, err := m.Up(migrations[:1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 1 {
+		t.Errorf("expected 1 applied migration, got %d", len(applied))
+	}
+	if applied, err = m.Up(migrations); err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 1 || applied[0].Version != 2 {
+		t.Errorf("expected the migration 2 to be applied, got %v", applied)
+	}
+	if applied, err = m.Up(migrations); err != nil || len(applied) != 0 {
+		t.Errorf("expected no migration to be applied, got %v (%v)", applied, err)
+	}
+
+	rows, err := session.Exec("SELECT name, email FROM golang_driver.accounts WHERE id = 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		t.Fatal("expected 1 row")
+	}
+
+	recorded, err := m.Applied()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recorded) != 2 || recorded[0].Checksum != migrations[0].Checksum || recorded[1].Name != "add_email" {
+		t.Errorf("unexpected applied migrations %v", recorded)
+	}
+
+	changed := append([]migrate.Migration(nil), migrations...)
+	changed[1] = migrate.NewMigration(2, "add_email", "ALTER TABLE golang_driver.accounts ADD mail text;")
+	if _, err := m.Up(changed); err == nil {
+		t.Error("expected an error for a changed migration")
+	}
+}
+
+var (
+	migrateSetup = []string{
+		"CREATE KEYSPACE IF NOT EXISTS golang_driver WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};",
+	}
+
+	migrateCleanup = []string{
+		"DROP TABLE IF EXISTS golang_driver.accounts",
+		"DROP TABLE IF EXISTS golang_driver.schema_migrations",
+		"DROP TABLE IF EXISTS golang_driver.schema_migrations_lock",
+	}
+)
@@ -0,0 +1,168 @@
+package migrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"golang-driver/cassandra/internal/cqltext"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A versioned CQL script.
+type Migration struct {
+	Version int64
+	// the description of the migration, e.g. create_users
+	Name   string
+	Script string
+	// the hexadecimal SHA-256 of the script, which must not change once
+	// the migration is applied
+	Checksum string
+}
+
+// Creates a migration from its script.
+func NewMigration(version int64, name, script string) Migration {
+	sum := sha256.Sum256([]byte(script))
+	return Migration{
+		Version:  version,
+		Name:     name,
+		Script:   script,
+		Checksum: hex.EncodeToString(sum[:]),
+	}
+}
+
+// Returns the statements of the script (see Split).
+func (m Migration) Statements() []string {
+	return Split(m.Script)
+}
+
+func (m Migration) String() string {
+	if m.Name == "" {
+		return strconv.FormatInt(m.Version, 10)
+	}
+	return strconv.FormatInt(m.Version, 10) + " " + m.Name
+}
+
+// Reads the migrations from the .cql files of the top directory of fsys
+// (e.g. os.DirFS(dir) or an embed.FS), which are named
+// <version>_<name>.cql, e.g. 001_create_users.cql, and returns them in
+// the order of their versions. The other files are ignored.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	var migrations []Migration
+	for _, entry := range entries {
+		file := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(file, ".cql") {
+			continue
+		}
+		versionStr, name, _ := strings.Cut(strings.TrimSuffix(file, ".cql"), "_")
+		version, err := strconv.ParseInt(versionStr, 10, 64)
+		if err != nil || version < 0 {
+			return nil, fmt.Errorf("invalid migration file name %q: expected <version>_<name>.cql", file)
+		}
+		script, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, NewMigration(version, name, string(script)))
+	}
+	return sorted(migrations)
+}
+
+// returns a copy of the migrations in the order of their versions,
+// which must be unique
+func sorted(migrations []Migration) ([]Migration, error) {
+	migrations = append([]Migration(nil), migrations...)
+	sort.SliceStable(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version == migrations[i-1].Version {
+			return nil, fmt.Errorf("migrations %s and %s have the same version",
+				migrations[i-1].String(), migrations[i].String())
+		}
+	}
+	return migrations, nil
+}
+
+// Splits a script into its statements, which are terminated by
+// semicolons. The semicolons in strings, quoted identifiers, comments
+// and $$ function bodies don't end statements, neither do the ones
+// separating the statements of a batch (BEGIN BATCH ... APPLY BATCH).
+// The statements keep their leading comments; the ones holding only
+// comments are dropped.
+func Split(script string) []string {
+	var statements []string
+	add := func(statement string) {
+		if skipComments(statement) != "" {
+			statements = append(statements, strings.TrimSpace(statement))
+		}
+	}
+	start := 0
+	for i := 0; i < len(script); {
+		if j := cqltext.Skip(script, i); j > i {
+			i = j
+			continue
+		}
+		if script[i] == ';' {
+			statement := script[start:i]
+			if keyword(statement) != "BEGIN" || endsBatch(statement) {
+				add(statement)
+				start = i + 1
+			}
+		}
+		i++
+	}
+	add(script[start:])
+	return statements
+}
+
+// returns the first keyword of the statement in upper case, e.g.
+// CREATE, skipping its leading comments.
+func keyword(statement string) string {
+	rest := skipComments(statement)
+	i := 0
+	for i < len(rest) && isLetter(rest[i]) {
+		i++
+	}
+	return strings.ToUpper(rest[:i])
+}
+
+// returns the statement without its leading spaces and comments
+func skipComments(statement string) string {
+	i := 0
+	for i < len(statement) {
+		if j := cqltext.Skip(statement, i); j > i && isComment(statement[i:]) {
+			i = j
+		} else if isSpace(statement[i]) {
+			i++
+		} else {
+			break
+		}
+	}
+	return statement[i:]
+}
+
+func endsBatch(statement string) bool {
+	words := strings.Fields(statement)
+	return len(words) >= 2 &&
+		strings.EqualFold(words[len(words)-2], "APPLY") &&
+		strings.EqualFold(words[len(words)-1], "BATCH")
+}
+
+func isComment(s string) bool {
+	return strings.HasPrefix(s, "--") || strings.HasPrefix(s, "//") || strings.HasPrefix(s, "/*")
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,138 @@
+package cassandra_test
+
+import (
+	"golang-driver/cassandra"
+	"golang-driver/cassandra/test"
+	"reflect"
+	"testing"
+)
+
+func TestNestedCollections(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	if err := test.Setup(nestedSetup); err != nil {
+		t.Log("Unexpected error while setup. You might need to clean up manually golang_driver keyspace")
+		t.Fatal(err)
+	}
+	defer test.TearDown(nestedCleanup)
+
+	testInsertNestedUsingPreparedStatement(t, session)
+	testInsertNestedUsingStatement(t, session)
+	testReadNestedIntoInterface(t, session)
+}
+
+var (
+	nestedLists  = map[string][]int32{"a": {1, 2, 3}, "b": {}}
+	nestedSets   = []map[string]bool{{"x": true}, {"y": true, "z": true}}
+	nestedTuple  = cassandra.NewTuple(nestedTupleType, int32(7), []string{"p", "q"})
+	nestedTuples = []*cassandra.Tuple{nestedTuple}
+)
+
+var nestedTupleType = cassandra.CTuple.Specialize(cassandra.CInt,
+	cassandra.CList.Specialize(cassandra.CText))
+
+func testInsertNestedUsingPreparedStatement(t *testing.T, session *cassandra.Session) {
+	pstmt, err := session.Prepare("INSERT INTO golang_driver.nested (id, lists, sets, tup, tuples) VALUES (?, ?, ?, ?, ?)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pstmt.Close()
+
+	if _, err := pstmt.Exec(int32(1), nestedLists, nestedSets, nestedTuple, nestedTuples); err != nil {
+		t.Fatal(err)
+	}
+	checkNested(t, session, 1)
+}
+
+func testInsertNestedUsingStatement(t *testing.T, session *cassandra.Session) {
+	if _, err := session.Exec("INSERT INTO golang_driver.nested (id, lists, tup) VALUES (?, ?, ?)",
+		int32(2), nestedLists, nestedTuple); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := session.Exec("SELECT lists FROM golang_driver.nested WHERE id = 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		t.Fatal("expected a row")
+	}
+	var lists map[string][]int32
+	if err := rows.Scan(&lists); err != nil {
+		t.Fatal(err)
+	}
+	if len(lists) != 2 || !reflect.DeepEqual(lists["a"], nestedLists["a"]) {
+		t.Errorf("expected %v, got %v", nestedLists, lists)
+	}
+}
+
+func checkNested(t *testing.T, session *cassandra.Session, id int32) {
+	rows, err := session.Exec("SELECT lists, sets, tup, tuples FROM golang_driver.nested WHERE id = ?", id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		t.Fatal("expected a row")
+	}
+	var lists map[string][]int32
+	var sets []map[string]bool
+	var tup cassandra.Tuple
+	var tuples []cassandra.Tuple
+	if err := rows.Scan(&lists, &sets, &tup, &tuples); err != nil {
+		t.Fatal(err)
+	}
+	if len(lists) != 2 || !reflect.DeepEqual(lists["a"], nestedLists["a"]) || len(lists["b"]) != 0 {
+		t.Errorf("expected %v, got %v", nestedLists, lists)
+	}
+	if !reflect.DeepEqual(sets, nestedSets) {
+		t.Errorf("expected %v, got %v", nestedSets, sets)
+	}
+	if tup.Len() != 2 || !reflect.DeepEqual(tup.Get(1), []string{"p", "q"}) {
+		t.Errorf("expected %s, got %s", nestedTuple, tup)
+	}
+	if len(tuples) != 1 || !reflect.DeepEqual(tuples[0].Get(1), []string{"p", "q"}) {
+		t.Errorf("expected %v, got %v", nestedTuples, tuples)
+	}
+}
+
+func testReadNestedIntoInterface(t *testing.T, session *cassandra.Session) {
+	rows, err := session.Exec("SELECT lists, sets FROM golang_driver.nested WHERE id = 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		t.Fatal("expected a row")
+	}
+	var lists, sets interface{}
+	if err := rows.Scan(&lists, &sets); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := lists.(map[string][]int); !ok {
+		t.Errorf("expected map[string][]int, got %T", lists)
+	}
+	if _, ok := sets.([]map[string]bool); !ok {
+		t.Errorf("expected []map[string]bool, got %T", sets)
+	}
+}
+
+var (
+	nestedSetup = []string{
+		"CREATE KEYSPACE IF NOT EXISTS golang_driver WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};",
+		`CREATE TABLE IF NOT EXISTS golang_driver.nested (id int PRIMARY KEY,
+			lists map<text, frozen<list<int>>>,
+			sets list<frozen<set<text>>>,
+			tup tuple<int, list<text>>,
+			tuples list<frozen<tuple<int, list<text>>>>)`,
+	}
+
+	nestedCleanup = []string{
+		"DROP TABLE golang_driver.nested",
+	}
+)
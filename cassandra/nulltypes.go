@@ -0,0 +1,300 @@
+package cassandra
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// The Null* types hold values of nullable columns: Valid is false
+// when the value is null. They can be bound (an invalid value binds
+// null) and scanned into directly, in the same way as the type of
+// their value. They also implement sql.Scanner and driver.Valuer for
+// use with the sqldriver package.
+
+// implemented by the Null* types for binding
+type nullable interface {
+	// returns the value and false if it's null
+	nullValue() (interface{}, bool)
+}
+
+// implemented by pointers to the Null* types for scanning
+type nullableDst interface {
+	// resets the value to null, or to the zero value if valid, and
+	// returns a pointer the non-null values are read into
+	scanTarget(valid bool) interface{}
+}
+
+type NullString struct {
+	String string
+	Valid  bool
+}
+
+type NullBool struct {
+	Bool  bool
+	Valid bool
+}
+
+type NullInt8 struct {
+	Int8  int8
+	Valid bool
+}
+
+type NullInt16 struct {
+	Int16 int16
+	Valid bool
+}
+
+type NullInt32 struct {
+	Int32 int32
+	Valid bool
+}
+
+type NullInt64 struct {
+	Int64 int64
+	Valid bool
+}
+
+type NullFloat32 struct {
+	Float32 float32
+	Valid   bool
+}
+
+type NullFloat64 struct {
+	Float64 float64
+	Valid   bool
+}
+
+type NullTimestamp struct {
+	Timestamp Timestamp
+	Valid     bool
+}
+
+type NullDate struct {
+	Date  Date
+	Valid bool
+}
+
+type NullTime struct {
+	Time  Time
+	Valid bool
+}
+
+type NullUUID struct {
+	UUID  UUID
+	Valid bool
+}
+
+type NullDecimal struct {
+	Decimal Decimal
+	Valid   bool
+}
+
+type NullDuration struct {
+	Duration Duration
+	Valid    bool
+}
+
+func (n NullString) nullValue() (interface{}, bool)    { return n.String, n.Valid }
+func (n NullBool) nullValue() (interface{}, bool)      { return n.Bool, n.Valid }
+func (n NullInt8) nullValue() (interface{}, bool)      { return n.Int8, n.Valid }
+func (n NullInt16) nullValue() (interface{}, bool)     { return n.Int16, n.Valid }
+func (n NullInt32) nullValue() (interface{}, bool)     { return n.Int32, n.Valid }
+func (n NullInt64) nullValue() (interface{}, bool)     { return n.Int64, n.Valid }
+func (n NullFloat32) nullValue() (interface{}, bool)   { return n.Float32, n.Valid }
+func (n NullFloat64) nullValue() (interface{}, bool)   { return n.Float64, n.Valid }
+func (n NullTimestamp) nullValue() (interface{}, bool) { return n.Timestamp, n.Valid }
+func (n NullDate) nullValue() (interface{}, bool)      { return n.Date, n.Valid }
+func (n NullTime) nullValue() (interface{}, bool)      { return n.Time, n.Valid }
+func (n NullUUID) nullValue() (interface{}, bool)      { return n.UUID, n.Valid }
+func (n NullDecimal) nullValue() (interface{}, bool)   { return &n.Decimal, n.Valid }
+func (n NullDuration) nullValue() (interface{}, bool)  { return n.Duration, n.Valid }
+
+func (n *NullString) scanTarget(valid bool) interface{} {
+	*n = NullString{Valid: valid}
+	return &n.String
+}
+
+func (n *NullBool) scanTarget(valid bool) interface{} {
+	*n = NullBool{Valid: valid}
+	return &n.Bool
+}
+
+func (n *NullInt8) scanTarget(valid bool) interface{} {
+	*n = NullInt8{Valid: valid}
+	return &n.Int8
+}
+
+func (n *NullInt16) scanTarget(valid bool) interface{} {
+	*n = NullInt16{Valid: valid}
+	return &n.Int16
+}
+
+func (n *NullInt32) scanTarget(valid bool) interface{} {
+	*n = NullInt32{Valid: valid}
+	return &n.Int32
+}
+
+func (n *NullInt64) scanTarget(valid bool) interface{} {
+	*n = NullInt64{Valid: valid}
+	return &n.Int64
+}
+
+func (n *NullFloat32) scanTarget(valid bool) interface{} {
+	*n = NullFloat32{Valid: valid}
+	return &n.Float32
+}
+
+func (n *NullFloat64) scanTarget(valid bool) interface{} {
+	*n = NullFloat64{Valid: valid}
+	return &n.Float64
+}
+
+func (n *NullTimestamp) scanTarget(valid bool) interface{} {
+	*n = NullTimestamp{Valid: valid}
+	return &n.Timestamp
+}
+
+func (n *NullDate) scanTarget(valid bool) interface{} {
+	*n = NullDate{Valid: valid}
+	return &n.Date
+}
+
+func (n *NullTime) scanTarget(valid bool) interface{} {
+	*n = NullTime{Valid: valid}
+	return &n.Time
+}
+
+func (n *NullUUID) scanTarget(valid bool) interface{} {
+	*n = NullUUID{Valid: valid}
+	return &n.UUID
+}
+
+func (n *NullDecimal) scanTarget(valid bool) interface{} {
+	*n = NullDecimal{Valid: valid}
+	return &n.Decimal
+}
+
+func (n *NullDuration) scanTarget(valid bool) interface{} {
+	*n = NullDuration{Valid: valid}
+	return &n.Duration
+}
+
+func (n NullString) Value() (driver.Value, error)    { return nullableValue(n) }
+func (n NullBool) Value() (driver.Value, error)      { return nullableValue(n) }
+func (n NullInt8) Value() (driver.Value, error)      { return nullableValue(n) }
+func (n NullInt16) Value() (driver.Value, error)     { return nullableValue(n) }
+func (n NullInt32) Value() (driver.Value, error)     { return nullableValue(n) }
+func (n NullInt64) Value() (driver.Value, error)     { return nullableValue(n) }
+func (n NullFloat32) Value() (driver.Value, error)   { return nullableValue(n) }
+func (n NullFloat64) Value() (driver.Value, error)   { return nullableValue(n) }
+func (n NullTimestamp) Value() (driver.Value, error) { return nullableValue(n) }
+func (n NullDate) Value() (driver.Value, error)      { return nullableValue(n) }
+func (n NullTime) Value() (driver.Value, error)      { return nullableValue(n) }
+func (n NullUUID) Value() (driver.Value, error)      { return nullableValue(n) }
+func (n NullDecimal) Value() (driver.Value, error)   { return nullableValue(n) }
+func (n NullDuration) Value() (driver.Value, error)  { return nullableValue(n) }
+
+func (n *NullString) Scan(src interface{}) error    { return scanNullable(src, n) }
+func (n *NullBool) Scan(src interface{}) error      { return scanNullable(src, n) }
+func (n *NullInt8) Scan(src interface{}) error      { return scanNullable(src, n) }
+func (n *NullInt16) Scan(src interface{}) error     { return scanNullable(src, n) }
+func (n *NullInt32) Scan(src interface{}) error     { return scanNullable(src, n) }
+func (n *NullInt64) Scan(src interface{}) error     { return scanNullable(src, n) }
+func (n *NullFloat32) Scan(src interface{}) error   { return scanNullable(src, n) }
+func (n *NullFloat64) Scan(src interface{}) error   { return scanNullable(src, n) }
+func (n *NullTimestamp) Scan(src interface{}) error { return scanNullable(src, n) }
+func (n *NullDate) Scan(src interface{}) error      { return scanNullable(src, n) }
+func (n *NullTime) Scan(src interface{}) error      { return scanNullable(src, n) }
+func (n *NullUUID) Scan(src interface{}) error      { return scanNullable(src, n) }
+func (n *NullDecimal) Scan(src interface{}) error   { return scanNullable(src, n) }
+func (n *NullDuration) Scan(src interface{}) error  { return scanNullable(src, n) }
+
+// The values are returned as they are bound by this package, so they
+// aren't necessarily of the types defined by database/sql/driver.
+func nullableValue(n nullable) (driver.Value, error) {
+	v, valid := n.nullValue()
+	if !valid {
+		return nil, nil
+	}
+	return v, nil
+}
+
+// stores src, which can be either of the type of the value, a value
+// convertible to it, or its text (for the types implementing
+// encoding.TextUnmarshaler)
+func scanNullable(src interface{}, dst nullableDst) error {
+	if src == nil {
+		dst.scanTarget(false)
+		return nil
+	}
+	target := reflect.ValueOf(dst.scanTarget(true)).Elem()
+	if err := convertScanned(src, target); err != nil {
+		dst.scanTarget(false)
+		return fmt.Errorf("cannot scan %T into %T: %s", src, dst, err.Error())
+	}
+	return nil
+}
+
+func convertScanned(src interface{}, target reflect.Value) error {
+	srcVal := reflect.ValueOf(src)
+	if srcVal.Kind() == reflect.Ptr && !srcVal.IsNil() && srcVal.Elem().Type() == target.Type() {
+		srcVal = srcVal.Elem()
+	}
+	if srcVal.Type().AssignableTo(target.Type()) {
+		target.Set(srcVal)
+		return nil
+	}
+
+	switch src := src.(type) {
+	case time.Time:
+		switch target.Type() {
+		case reflect.TypeOf(Timestamp{}):
+			target.Set(reflect.ValueOf(NewTimestampFromTime(src)))
+			return nil
+		case reflect.TypeOf(Date{}):
+			year, month, day := src.Date()
+			date, err := newDate(int64(year), int64(month), int64(day))
+			if err != nil {
+				return err
+			}
+			target.Set(reflect.ValueOf(date))
+			return nil
+		}
+	case string, []byte:
+		if u, ok := target.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			text, _ := nativeText(src)
+			return u.UnmarshalText(text)
+		}
+	}
+
+	switch target.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch srcVal.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if target.OverflowInt(srcVal.Int()) {
+				return fmt.Errorf("%d overflows %s", srcVal.Int(), target.Type())
+			}
+			target.SetInt(srcVal.Int())
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		switch srcVal.Kind() {
+		case reflect.Float32, reflect.Float64:
+			target.SetFloat(srcVal.Float())
+			return nil
+		}
+	case reflect.String:
+		switch src := src.(type) {
+		case string:
+			target.SetString(src)
+			return nil
+		case []byte:
+			target.SetString(string(src))
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported conversion")
+}
@@ -0,0 +1,131 @@
+package cassandra_test
+
+import (
+	"golang-driver/cassandra"
+	"golang-driver/cassandra/test"
+	"testing"
+	"time"
+)
+
+func TestNullTypesScanAndValue(t *testing.T) {
+	var s cassandra.NullString
+	if err := s.Scan([]byte("abc")); err != nil || !s.Valid || s.String != "abc" {
+		t.Errorf("expected a valid abc, got %+v (%v)", s, err)
+	}
+	if err := s.Scan(nil); err != nil || s.Valid || s.String != "" {
+		t.Errorf("expected null, got %+v (%v)", s, err)
+	}
+
+	var i8 cassandra.NullInt8
+	if err := i8.Scan(int64(300)); err == nil {
+		t.Errorf("300 should overflow a NullInt8 (got %+v)", i8)
+	}
+	if err := i8.Scan(int64(-3)); err != nil || !i8.Valid || i8.Int8 != -3 {
+		t.Errorf("expected a valid -3, got %+v (%v)", i8, err)
+	}
+
+	u := cassandra.NewRandomUUID()
+	var nu cassandra.NullUUID
+	if err := nu.Scan(u.String()); err != nil || !nu.Valid || nu.UUID != u {
+		t.Errorf("expected a valid %s, got %+v (%v)", u, nu, err)
+	}
+
+	var ts cassandra.NullTimestamp
+	now := time.Unix(time.Now().Unix(), 0)
+	if err := ts.Scan(now); err != nil || !ts.Valid || !ts.Timestamp.Time().Equal(now) {
+		t.Errorf("expected a valid %s, got %+v (%v)", now, ts, err)
+	}
+
+	if v, err := (cassandra.NullInt64{Int64: 5}).Value(); err != nil || v != nil {
+		t.Errorf("an invalid value should be nil, got %v (%v)", v, err)
+	}
+	if v, err := (cassandra.NullInt64{Int64: 5, Valid: true}).Value(); err != nil || v != int64(5) {
+		t.Errorf("expected 5, got %v (%v)", v, err)
+	}
+}
+
+func TestNullTypes(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	if err := test.Setup(nullTypesSetup); err != nil {
+		t.Log("Unexpected error while setup. You might need to clean up manually golang_driver keyspace")
+		t.Fatal(err)
+	}
+	defer test.TearDown(nullTypesCleanup)
+
+	pstmt, err := session.Prepare("INSERT INTO golang_driver.sparse (id, name, qty, price, added, uid) VALUES (?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pstmt.Close()
+
+	u := cassandra.NewRandomUUID()
+	if _, err := pstmt.Exec(int32(1),
+		cassandra.NullString{"lamp", true},
+		cassandra.NullInt32{3, true},
+		cassandra.NullDecimal{*cassandra.NewDecimal(1950, 2), true},
+		cassandra.NullDate{mustDate(2016, 2, 29), true},
+		cassandra.NullUUID{u, true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pstmt.Exec(int32(2),
+		cassandra.NullString{}, cassandra.NullInt32{}, cassandra.NullDecimal{},
+		cassandra.NullDate{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	// simple statements bind invalid values as null too
+	if _, err := session.Exec("INSERT INTO golang_driver.sparse (id, name, qty) VALUES (?, ?, ?)",
+		int32(3), cassandra.NullString{"mug", true}, cassandra.NullInt32{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []int32{1, 2, 3} {
+		rows, err := session.Exec("SELECT name, qty, price, added, uid FROM golang_driver.sparse WHERE id = ?", id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !rows.Next() {
+			rows.Close()
+			t.Fatalf("expected row %d", id)
+		}
+		// start from valid values to check they're reset
+		name := cassandra.NullString{"x", true}
+		qty := cassandra.NullInt32{7, true}
+		price := cassandra.NullDecimal{*cassandra.NewDecimal(1, 0), true}
+		var added cassandra.NullDate
+		var uid cassandra.NullUUID
+		if err := rows.Scan(&name, &qty, &price, &added, &uid); err != nil {
+			t.Fatal(err)
+		}
+		rows.Close()
+
+		switch id {
+		case 1:
+			if name.String != "lamp" || qty.Int32 != 3 || price.Decimal.String() != "19.50" ||
+				added.Date != mustDate(2016, 2, 29) || uid.UUID != u ||
+				!name.Valid || !qty.Valid || !price.Valid || !added.Valid || !uid.Valid {
+				t.Errorf("unexpected values %+v %+v %+v %+v %+v", name, qty, price, added, uid)
+			}
+		case 2:
+			if name.Valid || qty.Valid || price.Valid || added.Valid || uid.Valid {
+				t.Errorf("expected nulls, got %+v %+v %+v %+v %+v", name, qty, price, added, uid)
+			}
+		case 3:
+			if !name.Valid || name.String != "mug" || qty.Valid || qty.Int32 != 0 {
+				t.Errorf("expected (mug, null), got %+v %+v", name, qty)
+			}
+		}
+	}
+}
+
+var (
+	nullTypesSetup = []string{
+		"CREATE KEYSPACE IF NOT EXISTS golang_driver WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};",
+		`CREATE TABLE IF NOT EXISTS golang_driver.sparse(id int PRIMARY KEY, name text, qty int, price decimal, added date, uid uuid)`,
+	}
+
+	nullTypesCleanup = []string{
+		"DROP TABLE golang_driver.sparse",
+	}
+)
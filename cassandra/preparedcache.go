@@ -0,0 +1,269 @@
+package cassandra
+
+// #cgo LDFLAGS: -L/usr/local/lib -lcassandra
+// #cgo CFLAGS: -I/usr/local/include
+// #include <stdlib.h>
+// #include <cassandra.h>
+import "C"
+import (
+	"container/list"
+	"golang-driver/cassandra/internal/cqltext"
+	"strings"
+	"sync"
+)
+
+// Statistics of the prepared statement cache of a Session.
+type PreparedCacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Size      int
+}
+
+// Enables a cache of prepared statements used transparently by
+// Exec() and ExecAsync(). Queries are prepared the first time they're
+// executed and the resulting PreparedStatements are reused for the
+// following executions of the same query in the same keyspace.
+//
+// The cache holds at most maxEntries statements, evicting the least
+// recently used ones, and it's emptied whenever the schema changes.
+// Only SELECT, INSERT, UPDATE, DELETE and BATCH queries are cached.
+// The unqualified tables of a prepared statement stay resolved in the
+// keyspace that was current when it was prepared, so the statements
+// are cached per keyspace: the one given to Cluster.ConnectKeyspace,
+// then the one of the last successful USE statement executed with the
+// session. The queries whose tables are all qualified by their
+// keyspace (e.g. shop.orders) are shared by all the keyspaces.
+// Calling this with maxEntries <= 0 disables the cache.
+func (session *Session) EnablePreparedStatementCache(maxEntries int) {
+	if session.stmtCache != nil {
+		session.stmtCache.purge()
+		session.stmtCache = nil
+	}
+	if maxEntries > 0 {
+		session.stmtCache = newPreparedCache(maxEntries)
+	}
+}
+
+// Returns the statistics of the prepared statement cache
+// (see EnablePreparedStatementCache).
+func (session *Session) PreparedStatementCacheStats() PreparedCacheStats {
+	if session.stmtCache == nil {
+		return PreparedCacheStats{}
+	}
+	return session.stmtCache.statistics()
+}
+
+func (session *Session) execCachedAsync(query string, args ...interface{}) *Future {
+	switch statementKeyword(query) {
+	case "SELECT", "INSERT", "UPDATE", "DELETE", "BEGIN":
+		key := preparedCacheKey{query: query}
+		if !qualified(query) {
+			key.keyspace = session.currentKeyspace()
+		}
+		entry, err := session.stmtCache.acquire(session, key)
+		if err != nil {
+			return &Future{err: err}
+		}
+		// the bound statement keeps the prepared statement alive
+		// so the entry can be released as soon as it's executed
+		defer session.stmtCache.release(entry)
+		return entry.pstmt.ExecAsync(args...)
+	case "CREATE", "ALTER", "DROP":
+		session.stmtCache.purge()
+	}
+	return session.execAsync(query, args...)
+}
+
+func statementKeyword(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(fields[0])
+}
+
+// Returns the keyspace switched to by a USE statement: as written if
+// it's quoted, in lower case otherwise.
+func usedKeyspace(query string) (string, bool) {
+	if statementKeyword(query) != "USE" {
+		return "", false
+	}
+	tokens := queryTokens(query)
+	if len(tokens) < 2 {
+		return "", false
+	}
+	keyspace := tokens[1]
+	if strings.HasPrefix(keyspace, `"`) {
+		return strings.ReplaceAll(keyspace[1:len(keyspace)-1], `""`, `"`), true
+	}
+	return strings.ToLower(keyspace), true
+}
+
+// Returns whether all the tables of the query (following FROM, INTO or
+// UPDATE) are qualified by their keyspace.
+func qualified(query string) bool {
+	tokens := queryTokens(query)
+	tables := 0
+	for i, token := range tokens {
+		switch strings.ToUpper(token) {
+		case "FROM", "INTO", "UPDATE":
+			if i+2 >= len(tokens) || tokens[i+2] != "." {
+				return false
+			}
+			tables++
+		}
+	}
+	return tables > 0
+}
+
+// Splits the query into identifiers, quoted identifiers and punctuation,
+// dropping the spaces, the comments and the literals of strings.
+func queryTokens(query string) []string {
+	var tokens []string
+	for i := 0; i < len(query); {
+		if j := cqltext.Skip(query, i); j > i {
+			if query[i] == '"' {
+				tokens = append(tokens, query[i:j])
+			}
+			i = j
+			continue
+		}
+		j := i + 1
+		switch c := query[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i = j
+			continue
+		case isIdentifierByte(c):
+			for j < len(query) && isIdentifierByte(query[j]) {
+				j++
+			}
+		}
+		tokens = append(tokens, query[i:j])
+		i = j
+	}
+	return tokens
+}
+
+type preparedCacheKey struct {
+	// empty for the queries whose tables are all qualified
+	keyspace string
+	query    string
+}
+
+type preparedCacheEntry struct {
+	key     preparedCacheKey
+	pstmt   *PreparedStatement
+	refs    int
+	evicted bool
+}
+
+type preparedCache struct {
+	sync.Mutex
+	maxEntries    int
+	entries       map[preparedCacheKey]*list.Element
+	lru           *list.List
+	schemaVersion uint32
+	stats         PreparedCacheStats
+}
+
+func newPreparedCache(maxEntries int) *preparedCache {
+	return &preparedCache{
+		maxEntries: maxEntries,
+		entries:    make(map[preparedCacheKey]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// Returns the cached entry for the query preparing it if needed.
+// The entry must be released once the statement was executed.
+func (cache *preparedCache) acquire(session *Session, key preparedCacheKey) (*preparedCacheEntry, error) {
+	version := schemaVersion(session)
+
+	cache.Lock()
+	if version != cache.schemaVersion {
+		cache.evictAll()
+		cache.schemaVersion = version
+	}
+	if elem, ok := cache.entries[key]; ok {
+		cache.lru.MoveToFront(elem)
+		entry := elem.Value.(*preparedCacheEntry)
+		entry.refs++
+		cache.stats.Hits++
+		cache.Unlock()
+		return entry, nil
+	}
+	cache.stats.Misses++
+	cache.Unlock()
+
+	pstmt, err := session.Prepare(key.query)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.Lock()
+	defer cache.Unlock()
+	// another goroutine may have prepared the same query meanwhile
+	if elem, ok := cache.entries[key]; ok {
+		pstmt.Close()
+		cache.lru.MoveToFront(elem)
+		entry := elem.Value.(*preparedCacheEntry)
+		entry.refs++
+		return entry, nil
+	}
+	entry := &preparedCacheEntry{key: key, pstmt: pstmt, refs: 1}
+	cache.entries[key] = cache.lru.PushFront(entry)
+	for cache.lru.Len() > cache.maxEntries {
+		cache.evict(cache.lru.Back())
+	}
+	return entry, nil
+}
+
+func (cache *preparedCache) release(entry *preparedCacheEntry) {
+	cache.Lock()
+	defer cache.Unlock()
+	entry.refs--
+	if entry.evicted && entry.refs == 0 {
+		entry.pstmt.Close()
+	}
+}
+
+func (cache *preparedCache) purge() {
+	cache.Lock()
+	defer cache.Unlock()
+	cache.evictAll()
+}
+
+func (cache *preparedCache) statistics() PreparedCacheStats {
+	cache.Lock()
+	defer cache.Unlock()
+	stats := cache.stats
+	stats.Size = cache.lru.Len()
+	return stats
+}
+
+// must be called with the lock held
+func (cache *preparedCache) evictAll() {
+	for cache.lru.Len() > 0 {
+		cache.evict(cache.lru.Back())
+	}
+}
+
+// must be called with the lock held
+func (cache *preparedCache) evict(elem *list.Element) {
+	entry := cache.lru.Remove(elem).(*preparedCacheEntry)
+	delete(cache.entries, entry.key)
+	cache.stats.Evictions++
+	entry.evicted = true
+	if entry.refs == 0 {
+		entry.pstmt.Close()
+	}
+}
+
+// Returns the version of the session's schema metadata snapshot, which
+// changes with every schema change (if schema metadata is enabled).
+func schemaVersion(session *Session) uint32 {
+	meta := C.cass_session_get_schema_meta(session.cptr)
+	defer C.cass_schema_meta_free(meta)
+	return uint32(C.cass_schema_meta_snapshot_version(meta))
+}
@@ -0,0 +1,161 @@
+package cassandra_test
+
+import (
+	"golang-driver/cassandra"
+	"golang-driver/cassandra/test"
+	"testing"
+)
+
+func TestPreparedStatementCache(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	session.EnablePreparedStatementCache(2)
+	defer session.EnablePreparedStatementCache(0)
+
+	queries := []string{
+		"select key from system.local",
+		"select key from system.local",
+		"select release_version from system.local",
+		"select cluster_name from system.local",
+		"select key from system.local",
+	}
+	for _, query := range queries {
+		rows, err := session.Exec(query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows.Close()
+	}
+
+	stats := session.PreparedStatementCacheStats()
+	if stats.Hits != 1 || stats.Misses != 4 {
+		t.Errorf("expected 1 hit and 4 misses, got %d hits and %d misses",
+			stats.Hits, stats.Misses)
+	}
+	if stats.Size != 2 {
+		t.Errorf("expected 2 cached statements, got %d", stats.Size)
+	}
+	if stats.Evictions != 2 {
+		t.Errorf("expected 2 evictions, got %d", stats.Evictions)
+	}
+}
+
+func TestPreparedStatementCacheBoundValues(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	session.EnablePreparedStatementCache(10)
+	defer session.EnablePreparedStatementCache(0)
+
+	for i := 0; i < 3; i++ {
+		rows, err := session.Exec("select keyspace_name from system.schema_keyspaces where keyspace_name = ?", "system")
+		if err != nil {
+			t.Fatal(err)
+		}
+		test.IterateRows(rows, t)
+		rows.Close()
+	}
+
+	if stats := session.PreparedStatementCacheStats(); stats.Hits != 2 {
+		t.Errorf("expected 2 hits, got %d", stats.Hits)
+	}
+}
+
+func TestPreparedStatementCacheKeyspace(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	if err := test.Setup(preparedCacheSetup); err != nil {
+		t.Log("Unexpected error while setup. You might need to clean up manually golang_driver keyspace")
+		t.Fatal(err)
+	}
+	defer test.TearDown(preparedCacheCleanup)
+
+	session.EnablePreparedStatementCache(10)
+	defer session.EnablePreparedStatementCache(0)
+
+	// checks that the unqualified query reads the table of the keyspace
+	check := func(session *cassandra.Session, keyspace string) {
+		t.Helper()
+		rows, err := session.Exec("SELECT v FROM cached WHERE id = ?", 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+		var v string
+		if !rows.Next() {
+			t.Fatalf("expected 1 row in %s", keyspace)
+		}
+		if err := rows.Scan(&v); err != nil {
+			t.Fatal(err)
+		} else if v != keyspace {
+			t.Errorf("%s != %s (expected)", v, keyspace)
+		}
+	}
+
+	for i, keyspace := range []string{"golang_driver", "golang_driver_other", "golang_driver", "golang_driver_other"} {
+		if i%2 == 0 {
+			rows, err := session.Exec("USE " + keyspace)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rows.Close()
+		} else {
+			// the keyspace is switched without going through Session.Exec
+			use, err := session.Query(`USE "` + keyspace + `"`)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rows, err := use.Exec()
+			use.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			rows.Close()
+		}
+		check(session, keyspace)
+	}
+	// a failed USE doesn't switch the keyspace
+	if rows, err := session.Exec("USE golang_driver_missing"); err == nil {
+		rows.Close()
+		t.Error("expected an error for a missing keyspace")
+	}
+	check(session, "golang_driver_other")
+
+	stats := session.PreparedStatementCacheStats()
+	if stats.Size != 2 || stats.Hits != 3 {
+		t.Errorf("expected the query to be cached once per keyspace, got %+v", stats)
+	}
+
+	// the keyspace of the connection is used too
+	cluster := cassandra.NewCluster("127.0.0.1")
+	defer cluster.Close()
+	other, err := cluster.ConnectKeyspace("golang_driver_other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+	other.EnablePreparedStatementCache(10)
+	check(other, "golang_driver_other")
+	check(other, "golang_driver_other")
+	if stats := other.PreparedStatementCacheStats(); stats.Size != 1 || stats.Hits != 1 {
+		t.Errorf("expected the query to be cached, got %+v", stats)
+	}
+}
+
+var (
+	preparedCacheSetup = []string{
+		"CREATE KEYSPACE IF NOT EXISTS golang_driver WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};",
+		"CREATE KEYSPACE IF NOT EXISTS golang_driver_other WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};",
+		"CREATE TABLE IF NOT EXISTS golang_driver.cached (id int PRIMARY KEY, v text)",
+		"CREATE TABLE IF NOT EXISTS golang_driver_other.cached (id int PRIMARY KEY, v text)",
+		"INSERT INTO golang_driver.cached (id, v) VALUES (1, 'golang_driver')",
+		"INSERT INTO golang_driver_other.cached (id, v) VALUES (1, 'golang_driver_other')",
+	}
+
+	preparedCacheCleanup = []string{
+		"DROP TABLE golang_driver.cached",
+		"DROP KEYSPACE golang_driver_other",
+	}
+)
@@ -0,0 +1,322 @@
+// Package qb builds CQL statements. The builders render the
+// identifiers (quoted when needed) and the bind markers of the values,
+// which are returned separately to be bound by Session.Exec, Query or
+// PreparedStatement.Exec:
+//
+//	query, args := qb.Select("shop.orders", "id", "total").
+//		Where(qb.Eq("customer", id), qb.Gt("placed", since)).
+//		OrderBy("placed", qb.Desc).
+//		Limit(10).
+//		Build()
+//	rows, err := session.Exec(query, args...)
+//
+// Values are never rendered into the CQL text (except for the options
+// of the schema statements, which cannot be bound), so they cannot
+// alter the statement. A Marker is rendered as a bind marker without a
+// value, for the statements to prepare.
+//
+// A keyspace and a table (or a type, or an index) are separated by a
+// dot, e.g. "shop.orders".
+package qb
+
+import (
+	"fmt"
+	"golang-driver/cassandra/internal/cqltext"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Implemented by all the builders.
+type Builder interface {
+	// Returns the CQL text of the statement and the values of its bind
+	// markers.
+	Build() (string, []interface{})
+}
+
+// A bind marker without a value: ? or a named marker (e.g. :id).
+type Marker struct {
+	name string
+}
+
+// Returns the positional marker ?.
+func Param() Marker {
+	return Marker{}
+}
+
+// Returns the named marker :name.
+func NamedParam(name string) Marker {
+	return Marker{name}
+}
+
+func (m Marker) String() string {
+	if m.name == "" {
+		return "?"
+	}
+	return ":" + cqltext.QuoteIdentifier(m.name)
+}
+
+// Returns the identifier as it must be written in CQL: unchanged if
+// it's lower case and not a reserved keyword, or double quoted (e.g.
+// "userId" or "order") otherwise (see cql.QuoteIdentifier).
+func QuoteIdentifier(name string) string {
+	return cqltext.QuoteIdentifier(name)
+}
+
+func quoteAll(names []string) []string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = cqltext.QuoteIdentifier(name)
+	}
+	return quoted
+}
+
+// the CQL text and values of a statement being built
+type buffer struct {
+	strings.Builder
+	args []interface{}
+}
+
+// writes the marker of the value
+func (b *buffer) bind(v interface{}) {
+	if m, ok := v.(Marker); ok {
+		b.WriteString(m.String())
+		return
+	}
+	b.WriteString("?")
+	b.args = append(b.args, v)
+}
+
+// writes the markers of the values separated by commas
+func (b *buffer) bindAll(values []interface{}) {
+	for i, v := range values {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.bind(v)
+	}
+}
+
+func (b *buffer) build() (string, []interface{}) {
+	return b.String(), b.args
+}
+
+// The order of the rows.
+type Order int
+
+const (
+	Asc Order = iota
+	Desc
+)
+
+func (o Order) String() string {
+	if o == Desc {
+		return "DESC"
+	}
+	return "ASC"
+}
+
+// The left-hand side of a condition: a column, the token of the
+// partition key columns or a tuple of clustering columns.
+type Term struct {
+	cql string
+	// the values compared with a token are token(?, ...) and with a
+	// tuple (?, ...)
+	kind termKind
+}
+
+type termKind int
+
+const (
+	columnTerm termKind = iota
+	tokenTerm
+	tupleTerm
+)
+
+// Returns the term of a column.
+func Col(name string) Term {
+	return Term{cqltext.QuoteIdentifier(name), columnTerm}
+}
+
+// Returns the token of the given partition key columns, e.g.
+// qb.Token("id").Gt(42) renders token(id) > token(?).
+func Token(columns ...string) Term {
+	return Term{"token(" + strings.Join(quoteAll(columns), ", ") + ")", tokenTerm}
+}
+
+// Returns the tuple of the given clustering columns, e.g.
+// qb.Tuple("day", "hour").Ge(d, h) renders (day, hour) >= (?, ?).
+func Tuple(columns ...string) Term {
+	return Term{"(" + strings.Join(quoteAll(columns), ", ") + ")", tupleTerm}
+}
+
+// A condition of a WHERE or IF clause.
+type Condition struct {
+	term   Term
+	op     string
+	values []interface{}
+	// the values are a list, e.g. IN (?, ?)
+	list bool
+}
+
+func (t Term) compare(op string, values []interface{}) Condition {
+	return Condition{term: t, op: op, values: values}
+}
+
+func (t Term) Eq(values ...interface{}) Condition { return t.compare("=", values) }
+func (t Term) Ne(values ...interface{}) Condition { return t.compare("!=", values) }
+func (t Term) Lt(values ...interface{}) Condition { return t.compare("<", values) }
+func (t Term) Le(values ...interface{}) Condition { return t.compare("<=", values) }
+func (t Term) Gt(values ...interface{}) Condition { return t.compare(">", values) }
+func (t Term) Ge(values ...interface{}) Condition { return t.compare(">=", values) }
+
+// Renders IN (?, ...) with a marker per value, or IN ? if the only value
+// is a Marker (bound to a list).
+func (t Term) In(values ...interface{}) Condition {
+	return Condition{term: t, op: "IN", values: values, list: true}
+}
+
+// Renders CONTAINS ?, for a collection column.
+func (t Term) Contains(value interface{}) Condition {
+	return t.compare("CONTAINS", []interface{}{value})
+}
+
+// Renders CONTAINS KEY ?, for a map column.
+func (t Term) ContainsKey(value interface{}) Condition {
+	return t.compare("CONTAINS KEY", []interface{}{value})
+}
+
+func Eq(column string, value interface{}) Condition { return Col(column).Eq(value) }
+func Ne(column string, value interface{}) Condition { return Col(column).Ne(value) }
+func Lt(column string, value interface{}) Condition { return Col(column).Lt(value) }
+func Le(column string, value interface{}) Condition { return Col(column).Le(value) }
+func Gt(column string, value interface{}) Condition { return Col(column).Gt(value) }
+func Ge(column string, value interface{}) Condition { return Col(column).Ge(value) }
+
+func In(column string, values ...interface{}) Condition {
+	return Col(column).In(values...)
+}
+
+func Contains(column string, value interface{}) Condition {
+	return Col(column).Contains(value)
+}
+
+func ContainsKey(column string, value interface{}) Condition {
+	return Col(column).ContainsKey(value)
+}
+
+func (c Condition) write(b *buffer) {
+	b.WriteString(c.term.cql)
+	b.WriteString(" ")
+	b.WriteString(c.op)
+	b.WriteString(" ")
+	if c.list {
+		if len(c.values) == 1 {
+			if m, ok := c.values[0].(Marker); ok {
+				b.WriteString(m.String())
+				return
+			}
+		}
+		b.WriteString("(")
+		b.bindAll(c.values)
+		b.WriteString(")")
+		return
+	}
+	switch c.term.kind {
+	case tokenTerm:
+		b.WriteString("token(")
+		b.bindAll(c.values)
+		b.WriteString(")")
+	case tupleTerm:
+		b.WriteString("(")
+		b.bindAll(c.values)
+		b.WriteString(")")
+	default:
+		b.bindAll(c.values)
+	}
+}
+
+// writes " <keyword> a AND b ..."
+func writeConditions(b *buffer, keyword string, conds []Condition) {
+	for i, c := range conds {
+		if i == 0 {
+			b.WriteString(" " + keyword + " ")
+		} else {
+			b.WriteString(" AND ")
+		}
+		c.write(b)
+	}
+}
+
+// the USING clause of a write
+type using struct {
+	ttl       interface{}
+	timestamp interface{}
+}
+
+func (u *using) write(b *buffer) {
+	if u.ttl == nil && u.timestamp == nil {
+		return
+	}
+	b.WriteString(" USING ")
+	if u.ttl != nil {
+		b.WriteString("TTL ")
+		b.bind(u.ttl)
+		if u.timestamp != nil {
+			b.WriteString(" AND ")
+		}
+	}
+	if u.timestamp != nil {
+		b.WriteString("TIMESTAMP ")
+		b.bind(u.timestamp)
+	}
+}
+
+// the TTL of a write, bound as seconds; a duration is rounded up to a
+// whole second, as a TTL of 0 would mean no TTL
+func ttlValue(ttl interface{}) interface{} {
+	if d, ok := ttl.(time.Duration); ok {
+		seconds := d / time.Second
+		if d%time.Second > 0 {
+			seconds++
+		}
+		return int32(seconds)
+	}
+	return ttl
+}
+
+// Renders the value of an option of a schema statement, which cannot be
+// bound: a string, a number, a boolean or a map of options.
+func literal(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return cqltext.QuoteString(v)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		entries := make([]string, len(keys))
+		for i, k := range keys {
+			entries[i] = literal(k) + ": " + literal(v[k])
+		}
+		return "{" + strings.Join(entries, ", ") + "}"
+	case map[string]string:
+		m := make(map[string]interface{}, len(v))
+		for k, s := range v {
+			m[k] = s
+		}
+		return literal(m)
+	case map[string]int:
+		m := make(map[string]interface{}, len(v))
+		for k, n := range v {
+			m[k] = n
+		}
+		return literal(m)
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprint(v)
+	}
+	// unknown values are rendered as strings
+	return literal(fmt.Sprint(v))
+}
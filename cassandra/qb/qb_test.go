@@ -0,0 +1,114 @@
+package qb_test
+
+import (
+	"golang-driver/cassandra/qb"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func check(t *testing.T, b qb.Builder, cql string, args ...interface{}) {
+	t.Helper()
+	actualCql, actualArgs := b.Build()
+	if actualCql != cql {
+		t.Errorf("expected\n%s\ngot\n%s", cql, actualCql)
+	}
+	if len(args) != len(actualArgs) || (len(args) > 0 && !reflect.DeepEqual(args, actualArgs)) {
+		t.Errorf("expected values %v, got %v", args, actualArgs)
+	}
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	for name, quoted := range map[string]string{
+		"id":        "id",
+		"user_id2":  "user_id2",
+		"userId":    `"userId"`,
+		"order":     `"order"`,
+		"2fa":       `"2fa"`,
+		`say "hi"`:  `"say ""hi"""`,
+		"":          `""`,
+		"key":       "key",
+		"ks_1.tbl":  `"ks_1.tbl"`,
+		"_internal": `"_internal"`,
+	} {
+		if actual := qb.QuoteIdentifier(name); actual != quoted {
+			t.Errorf("%q: expected %s, got %s", name, quoted, actual)
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	check(t, qb.Select("users"), "SELECT * FROM users")
+	check(t, qb.Select("Shop.orders", "id", "userId", "from").
+		Where(qb.Eq("customer", 42), qb.Ge("placed", int64(1000)), qb.Lt("placed", int64(2000))).
+		OrderBy("placed", qb.Desc).
+		Limit(10),
+		`SELECT id, "userId", "from" FROM "Shop".orders WHERE customer = ? AND placed >= ? AND placed < ? ORDER BY placed DESC LIMIT 10`,
+		42, int64(1000), int64(2000))
+	check(t, qb.Select("ks.events").Json().
+		Where(qb.In("day", "mon", "tue"), qb.Tuple("hour", "minute").Gt(3, 30)).
+		PerPartitionLimit(2).
+		Limit(qb.Param()),
+		`SELECT JSON * FROM ks.events WHERE day IN (?, ?) AND (hour, minute) > (?, ?) PER PARTITION LIMIT 2 LIMIT ?`,
+		"mon", "tue", 3, 30)
+	check(t, qb.Select("ks.events").Distinct().
+		Where(qb.Token("day").Gt(qb.Param()), qb.Token("day").Le("sun")),
+		`SELECT DISTINCT * FROM ks.events WHERE token(day) > token(?) AND token(day) <= token(?)`,
+		"sun")
+	check(t, qb.Select("items").
+		Where(qb.Contains("tags", "red"), qb.ContainsKey("attrs", "size"), qb.In("id", qb.NamedParam("ids"))).
+		AllowFiltering(),
+		`SELECT * FROM items WHERE tags CONTAINS ? AND attrs CONTAINS KEY ? AND id IN :ids ALLOW FILTERING`,
+		"red", "size")
+	check(t, qb.Select("items", "category").Count().Fn("max", "Price").As("highest").
+		TTL("name").WriteTime("name").As("written").
+		GroupBy("category"),
+		`SELECT category, COUNT(*), max("Price") AS highest, TTL(name), WRITETIME(name) AS written FROM items GROUP BY category`)
+}
+
+func TestInsert(t *testing.T) {
+	check(t, qb.Insert("ks.users").Value("id", 1).Value("Name", "joe").Value("nick", nil),
+		`INSERT INTO ks.users (id, "Name", nick) VALUES (?, ?, ?)`, 1, "joe", nil)
+	check(t, qb.Insert("users").Value("id", qb.Param()).Value("name", qb.NamedParam("name")).
+		IfNotExists().TTL(time.Hour).Timestamp(int64(1234)),
+		`INSERT INTO users (id, name) VALUES (?, :name) IF NOT EXISTS USING TTL ? AND TIMESTAMP ?`,
+		int32(3600), int64(1234))
+	check(t, qb.Insert("users").Json(`{"id": 1}`).TTL(qb.Param()),
+		`INSERT INTO users JSON ? USING TTL ?`, `{"id": 1}`)
+	// sub-second durations are rounded up rather than bound as no TTL
+	check(t, qb.Insert("users").Value("id", 1).TTL(500*time.Millisecond),
+		`INSERT INTO users (id) VALUES (?) USING TTL ?`, 1, int32(1))
+	check(t, qb.Insert("users").Value("id", 1).TTL(90*time.Second+time.Millisecond),
+		`INSERT INTO users (id) VALUES (?) USING TTL ?`, 1, int32(91))
+}
+
+func TestUpdate(t *testing.T) {
+	check(t, qb.Update("ks.users").
+		Set("name", "joe").
+		Add("tags", []string{"a"}).
+		Remove("roles", []string{"admin"}).
+		Append("history", []int{1}).
+		Prepend("recent", []int{2}).
+		SetElement("attrs", "size", "XL").
+		Where(qb.Eq("id", 1)),
+		`UPDATE ks.users SET name = ?, tags = tags + ?, roles = roles - ?, history = history + ?, recent = ? + recent, attrs[?] = ? WHERE id = ?`,
+		"joe", []string{"a"}, []string{"admin"}, []int{1}, []int{2}, "size", "XL", 1)
+	check(t, qb.Update("stats").TTL(60).Timestamp(qb.Param()).
+		Increment("views", int64(1)).Decrement("Left", int64(2)).
+		Where(qb.Eq("page", "home")),
+		`UPDATE stats USING TTL ? AND TIMESTAMP ? SET views = views + ?, "Left" = "Left" - ? WHERE page = ?`,
+		60, int64(1), int64(2), "home")
+	check(t, qb.Update("users").Set("name", "joe").Where(qb.Eq("id", 1)).If(qb.Eq("name", "jo"), qb.Ne("locked", true)),
+		`UPDATE users SET name = ? WHERE id = ? IF name = ? AND locked != ?`, "joe", 1, "jo", true)
+	check(t, qb.Update("users").Set("name", "joe").Where(qb.Eq("id", 1)).IfExists(),
+		`UPDATE users SET name = ? WHERE id = ? IF EXISTS`, "joe", 1)
+}
+
+func TestDelete(t *testing.T) {
+	check(t, qb.Delete("ks.users").Where(qb.Eq("id", 1)).IfExists(),
+		`DELETE FROM ks.users WHERE id = ? IF EXISTS`, 1)
+	check(t, qb.Delete("users", "name", "Nick").Element("attrs", "size").Timestamp(int64(5)).
+		Where(qb.In("id", 1, 2)).If(qb.Eq("version", 3)),
+		`DELETE name, "Nick", attrs[?] FROM users USING TIMESTAMP ? WHERE id IN (?, ?) IF version = ?`,
+		"size", int64(5), 1, 2, 3)
+}
@@ -0,0 +1,388 @@
+package qb
+
+import (
+	"golang-driver/cassandra/internal/cqltext"
+	"strings"
+)
+
+// the options of a WITH clause, "name = value"
+type options []string
+
+func (o *options) add(name string, value interface{}) {
+	*o = append(*o, name+" = "+literal(value))
+}
+
+// writes " <keyword> a AND b ..."
+func (o options) write(b *buffer, keyword string) {
+	if len(o) > 0 {
+		b.WriteString(" " + keyword + " " + strings.Join(o, " AND "))
+	}
+}
+
+type KeyspaceBuilder struct {
+	verb        string
+	name        string
+	ifNotExists bool
+	options     options
+}
+
+// Starts a CREATE KEYSPACE.
+func CreateKeyspace(name string) *KeyspaceBuilder {
+	return &KeyspaceBuilder{verb: "CREATE", name: name}
+}
+
+// Starts an ALTER KEYSPACE.
+func AlterKeyspace(name string) *KeyspaceBuilder {
+	return &KeyspaceBuilder{verb: "ALTER", name: name}
+}
+
+func (k *KeyspaceBuilder) IfNotExists() *KeyspaceBuilder {
+	k.ifNotExists = true
+	return k
+}
+
+// Sets the replication options, e.g. {'class': 'SimpleStrategy',
+// 'replication_factor': 3}.
+func (k *KeyspaceBuilder) Replication(options map[string]interface{}) *KeyspaceBuilder {
+	k.options.add("replication", options)
+	return k
+}
+
+// Replicates the data on the given number of nodes.
+func (k *KeyspaceBuilder) SimpleStrategy(replicationFactor int) *KeyspaceBuilder {
+	return k.Replication(map[string]interface{}{
+		"class":              "SimpleStrategy",
+		"replication_factor": replicationFactor,
+	})
+}
+
+// Replicates the data on the given number of nodes of each data center.
+func (k *KeyspaceBuilder) NetworkTopologyStrategy(replicationFactors map[string]int) *KeyspaceBuilder {
+	options := map[string]interface{}{"class": "NetworkTopologyStrategy"}
+	for dc, rf := range replicationFactors {
+		options[dc] = rf
+	}
+	return k.Replication(options)
+}
+
+func (k *KeyspaceBuilder) DurableWrites(durable bool) *KeyspaceBuilder {
+	k.options.add("durable_writes", durable)
+	return k
+}
+
+func (k *KeyspaceBuilder) Build() (string, []interface{}) {
+	var b buffer
+	b.WriteString(k.verb + " KEYSPACE ")
+	if k.ifNotExists {
+		b.WriteString("IF NOT EXISTS ")
+	}
+	b.WriteString(cqltext.QuoteIdentifier(k.name))
+	k.options.write(&b, "WITH")
+	return b.build()
+}
+
+type CreateTableBuilder struct {
+	name            string
+	ifNotExists     bool
+	columns         []string
+	partitionKey    []string
+	clusteringKey   []string
+	clusteringOrder []string
+	options         options
+}
+
+// Starts a CREATE TABLE.
+func CreateTable(name string) *CreateTableBuilder {
+	return &CreateTableBuilder{name: name}
+}
+
+func (c *CreateTableBuilder) IfNotExists() *CreateTableBuilder {
+	c.ifNotExists = true
+	return c
+}
+
+// Adds a column of the given CQL type (e.g. "map<text, int>").
+func (c *CreateTableBuilder) Column(name, cqlType string) *CreateTableBuilder {
+	c.columns = append(c.columns, cqltext.QuoteIdentifier(name)+" "+cqlType)
+	return c
+}
+
+// Adds a column shared by the rows of a partition.
+func (c *CreateTableBuilder) StaticColumn(name, cqlType string) *CreateTableBuilder {
+	return c.Column(name, cqlType+" STATIC")
+}
+
+func (c *CreateTableBuilder) PartitionKey(columns ...string) *CreateTableBuilder {
+	c.partitionKey = append(c.partitionKey, quoteAll(columns)...)
+	return c
+}
+
+func (c *CreateTableBuilder) ClusteringKey(columns ...string) *CreateTableBuilder {
+	c.clusteringKey = append(c.clusteringKey, quoteAll(columns)...)
+	return c
+}
+
+// Sets the order of a clustering column (all of them must be given if
+// one is).
+func (c *CreateTableBuilder) ClusteringOrder(column string, order Order) *CreateTableBuilder {
+	c.clusteringOrder = append(c.clusteringOrder, cqltext.QuoteIdentifier(column)+" "+order.String())
+	return c
+}
+
+// Sets a table option, e.g. With("default_time_to_live", 3600) or
+// With("compaction", map[string]interface{}{"class": "LeveledCompactionStrategy"}).
+func (c *CreateTableBuilder) With(option string, value interface{}) *CreateTableBuilder {
+	c.options.add(option, value)
+	return c
+}
+
+func (c *CreateTableBuilder) Build() (string, []interface{}) {
+	var b buffer
+	b.WriteString("CREATE TABLE ")
+	if c.ifNotExists {
+		b.WriteString("IF NOT EXISTS ")
+	}
+	b.WriteString(cqltext.QuoteName(c.name))
+	b.WriteString(" (" + strings.Join(c.columns, ", "))
+	if len(c.partitionKey) > 0 {
+		partitionKey := strings.Join(c.partitionKey, ", ")
+		if len(c.partitionKey) > 1 {
+			partitionKey = "(" + partitionKey + ")"
+		}
+		b.WriteString(", PRIMARY KEY (" + strings.Join(append([]string{partitionKey}, c.clusteringKey...), ", ") + ")")
+	}
+	b.WriteString(")")
+	options := c.options
+	if len(c.clusteringOrder) > 0 {
+		options = append(options[:0:0], "CLUSTERING ORDER BY ("+strings.Join(c.clusteringOrder, ", ")+")")
+		options = append(options, c.options...)
+	}
+	options.write(&b, "WITH")
+	return b.build()
+}
+
+// Alters a table or a type. Cassandra alters one thing per statement:
+// use one of Add, Drop, Rename or With.
+type AlterBuilder struct {
+	kind    string
+	name    string
+	adds    []string
+	drops   []string
+	renames []string
+	options options
+}
+
+// Starts an ALTER TABLE.
+func AlterTable(name string) *AlterBuilder {
+	return &AlterBuilder{kind: "TABLE", name: name}
+}
+
+// Starts an ALTER TYPE.
+func AlterType(name string) *AlterBuilder {
+	return &AlterBuilder{kind: "TYPE", name: name}
+}
+
+// Adds a column (or a field) of the given CQL type.
+func (a *AlterBuilder) Add(name, cqlType string) *AlterBuilder {
+	a.adds = append(a.adds, cqltext.QuoteIdentifier(name)+" "+cqlType)
+	return a
+}
+
+// Drops columns of a table.
+func (a *AlterBuilder) Drop(columns ...string) *AlterBuilder {
+	a.drops = append(a.drops, quoteAll(columns)...)
+	return a
+}
+
+// Renames a primary key column of a table, or a field of a type.
+func (a *AlterBuilder) Rename(from, to string) *AlterBuilder {
+	a.renames = append(a.renames, cqltext.QuoteIdentifier(from)+" TO "+cqltext.QuoteIdentifier(to))
+	return a
+}
+
+// Sets a table option (see CreateTableBuilder.With).
+func (a *AlterBuilder) With(option string, value interface{}) *AlterBuilder {
+	a.options.add(option, value)
+	return a
+}
+
+// writes " <keyword> a" or " <keyword> (a, b)"
+func writeList(b *buffer, keyword string, list []string) {
+	switch len(list) {
+	case 0:
+	case 1:
+		b.WriteString(" " + keyword + " " + list[0])
+	default:
+		b.WriteString(" " + keyword + " (" + strings.Join(list, ", ") + ")")
+	}
+}
+
+func (a *AlterBuilder) Build() (string, []interface{}) {
+	var b buffer
+	b.WriteString("ALTER " + a.kind + " ")
+	b.WriteString(cqltext.QuoteName(a.name))
+	if a.kind == "TYPE" {
+		// a type adds its fields one at a time
+		for _, add := range a.adds {
+			b.WriteString(" ADD " + add)
+		}
+	} else {
+		writeList(&b, "ADD", a.adds)
+	}
+	writeList(&b, "DROP", a.drops)
+	if len(a.renames) > 0 {
+		b.WriteString(" RENAME " + strings.Join(a.renames, " AND "))
+	}
+	a.options.write(&b, "WITH")
+	return b.build()
+}
+
+type CreateTypeBuilder struct {
+	name        string
+	ifNotExists bool
+	fields      []string
+}
+
+// Starts a CREATE TYPE.
+func CreateType(name string) *CreateTypeBuilder {
+	return &CreateTypeBuilder{name: name}
+}
+
+func (c *CreateTypeBuilder) IfNotExists() *CreateTypeBuilder {
+	c.ifNotExists = true
+	return c
+}
+
+// Adds a field of the given CQL type.
+func (c *CreateTypeBuilder) Field(name, cqlType string) *CreateTypeBuilder {
+	c.fields = append(c.fields, cqltext.QuoteIdentifier(name)+" "+cqlType)
+	return c
+}
+
+func (c *CreateTypeBuilder) Build() (string, []interface{}) {
+	var b buffer
+	b.WriteString("CREATE TYPE ")
+	if c.ifNotExists {
+		b.WriteString("IF NOT EXISTS ")
+	}
+	b.WriteString(cqltext.QuoteName(c.name))
+	b.WriteString(" (" + strings.Join(c.fields, ", ") + ")")
+	return b.build()
+}
+
+type CreateIndexBuilder struct {
+	name        string
+	table       string
+	target      string
+	ifNotExists bool
+	class       string
+	options     map[string]interface{}
+}
+
+// Starts a CREATE INDEX of the column of the table; the name may be
+// empty to let Cassandra name the index.
+func CreateIndex(name, table, column string) *CreateIndexBuilder {
+	return &CreateIndexBuilder{name: name, table: table, target: cqltext.QuoteIdentifier(column)}
+}
+
+func (c *CreateIndexBuilder) IfNotExists() *CreateIndexBuilder {
+	c.ifNotExists = true
+	return c
+}
+
+// Indexes the keys of a map column.
+func (c *CreateIndexBuilder) Keys() *CreateIndexBuilder {
+	c.target = "KEYS(" + c.target + ")"
+	return c
+}
+
+// Indexes the values of a collection column (the default).
+func (c *CreateIndexBuilder) Values() *CreateIndexBuilder {
+	c.target = "VALUES(" + c.target + ")"
+	return c
+}
+
+// Indexes the entries of a map column.
+func (c *CreateIndexBuilder) Entries() *CreateIndexBuilder {
+	c.target = "ENTRIES(" + c.target + ")"
+	return c
+}
+
+// Indexes a whole frozen collection.
+func (c *CreateIndexBuilder) Full() *CreateIndexBuilder {
+	c.target = "FULL(" + c.target + ")"
+	return c
+}
+
+// Creates a custom index of the given class (e.g.
+// "org.apache.cassandra.index.sasi.SASIIndex") with its options.
+func (c *CreateIndexBuilder) Using(class string, options map[string]interface{}) *CreateIndexBuilder {
+	c.class = class
+	c.options = options
+	return c
+}
+
+func (c *CreateIndexBuilder) Build() (string, []interface{}) {
+	var b buffer
+	b.WriteString("CREATE ")
+	if c.class != "" {
+		b.WriteString("CUSTOM ")
+	}
+	b.WriteString("INDEX ")
+	if c.ifNotExists {
+		b.WriteString("IF NOT EXISTS ")
+	}
+	if c.name != "" {
+		b.WriteString(cqltext.QuoteIdentifier(c.name) + " ")
+	}
+	b.WriteString("ON " + cqltext.QuoteName(c.table) + " (" + c.target + ")")
+	if c.class != "" {
+		b.WriteString(" USING " + literal(c.class))
+		if len(c.options) > 0 {
+			b.WriteString(" WITH OPTIONS = " + literal(c.options))
+		}
+	}
+	return b.build()
+}
+
+type DropBuilder struct {
+	kind     string
+	name     string
+	ifExists bool
+}
+
+// Starts a DROP KEYSPACE.
+func DropKeyspace(name string) *DropBuilder {
+	return &DropBuilder{kind: "KEYSPACE", name: name}
+}
+
+// Starts a DROP TABLE.
+func DropTable(name string) *DropBuilder {
+	return &DropBuilder{kind: "TABLE", name: name}
+}
+
+// Starts a DROP TYPE.
+func DropType(name string) *DropBuilder {
+	return &DropBuilder{kind: "TYPE", name: name}
+}
+
+// Starts a DROP INDEX; the name of the index may be qualified by its
+// keyspace.
+func DropIndex(name string) *DropBuilder {
+	return &DropBuilder{kind: "INDEX", name: name}
+}
+
+func (d *DropBuilder) IfExists() *DropBuilder {
+	d.ifExists = true
+	return d
+}
+
+func (d *DropBuilder) Build() (string, []interface{}) {
+	var b buffer
+	b.WriteString("DROP " + d.kind + " ")
+	if d.ifExists {
+		b.WriteString("IF EXISTS ")
+	}
+	b.WriteString(cqltext.QuoteName(d.name))
+	return b.build()
+}
@@ -0,0 +1,57 @@
+package qb_test
+
+import (
+	"golang-driver/cassandra/qb"
+	"testing"
+)
+
+func TestKeyspace(t *testing.T) {
+	check(t, qb.CreateKeyspace("golang_driver").IfNotExists().SimpleStrategy(1),
+		`CREATE KEYSPACE IF NOT EXISTS golang_driver WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1}`)
+	check(t, qb.AlterKeyspace("Shop").NetworkTopologyStrategy(map[string]int{"dc2": 2, "dc1": 3}).DurableWrites(false),
+		`ALTER KEYSPACE "Shop" WITH replication = {'class': 'NetworkTopologyStrategy', 'dc1': 3, 'dc2': 2} AND durable_writes = false`)
+	check(t, qb.DropKeyspace("shop").IfExists(), `DROP KEYSPACE IF EXISTS shop`)
+}
+
+func TestTable(t *testing.T) {
+	check(t, qb.CreateTable("ks.users").Column("id", "uuid").Column("Name", "text").PartitionKey("id"),
+		`CREATE TABLE ks.users (id uuid, "Name" text, PRIMARY KEY (id))`)
+	check(t, qb.CreateTable("ks.readings").IfNotExists().
+		Column("sensor", "text").
+		Column("day", "date").
+		Column("hour", "int").
+		Column("value", "double").
+		StaticColumn("unit", "text").
+		Column("tags", "map<text, frozen<list<int>>>").
+		PartitionKey("sensor", "day").
+		ClusteringKey("hour").
+		ClusteringOrder("hour", qb.Desc).
+		With("comment", "sensor's readings").
+		With("default_time_to_live", 86400).
+		With("compaction", map[string]interface{}{"class": "TimeWindowCompactionStrategy", "compaction_window_size": 1}),
+		`CREATE TABLE IF NOT EXISTS ks.readings (sensor text, day date, hour int, value double, unit text STATIC, tags map<text, frozen<list<int>>>, `+
+			`PRIMARY KEY ((sensor, day), hour)) WITH CLUSTERING ORDER BY (hour DESC) AND comment = 'sensor''s readings' AND default_time_to_live = 86400 `+
+			`AND compaction = {'class': 'TimeWindowCompactionStrategy', 'compaction_window_size': 1}`)
+	check(t, qb.AlterTable("ks.users").Add("email", "text"), `ALTER TABLE ks.users ADD email text`)
+	check(t, qb.AlterTable("users").Add("a", "int").Add("B", "text"), `ALTER TABLE users ADD (a int, "B" text)`)
+	check(t, qb.AlterTable("users").Drop("a", "B"), `ALTER TABLE users DROP (a, "B")`)
+	check(t, qb.AlterTable("users").Rename("id", "Key").Rename("c", "d"), `ALTER TABLE users RENAME id TO "Key" AND c TO d`)
+	check(t, qb.AlterTable("users").With("gc_grace_seconds", 0), `ALTER TABLE users WITH gc_grace_seconds = 0`)
+	check(t, qb.DropTable("ks.users"), `DROP TABLE ks.users`)
+}
+
+func TestTypeAndIndex(t *testing.T) {
+	check(t, qb.CreateType("ks.address").IfNotExists().Field("street", "text").Field("zip", "int"),
+		`CREATE TYPE IF NOT EXISTS ks.address (street text, zip int)`)
+	check(t, qb.AlterType("ks.address").Add("city", "text"), `ALTER TYPE ks.address ADD city text`)
+	check(t, qb.AlterType("ks.address").Rename("zip", "Zip"), `ALTER TYPE ks.address RENAME zip TO "Zip"`)
+	check(t, qb.DropType("ks.address").IfExists(), `DROP TYPE IF EXISTS ks.address`)
+
+	check(t, qb.CreateIndex("", "ks.users", "email"), `CREATE INDEX ON ks.users (email)`)
+	check(t, qb.CreateIndex("by_attr", "ks.users", "attrs").IfNotExists().Keys(),
+		`CREATE INDEX IF NOT EXISTS by_attr ON ks.users (KEYS(attrs))`)
+	check(t, qb.CreateIndex("by_name", "users", "name").
+		Using("org.apache.cassandra.index.sasi.SASIIndex", map[string]interface{}{"mode": "CONTAINS"}),
+		`CREATE CUSTOM INDEX by_name ON users (name) USING 'org.apache.cassandra.index.sasi.SASIIndex' WITH OPTIONS = {'mode': 'CONTAINS'}`)
+	check(t, qb.DropIndex("ks.by_name").IfExists(), `DROP INDEX IF EXISTS ks.by_name`)
+}
@@ -0,0 +1,149 @@
+package qb
+
+import (
+	"golang-driver/cassandra/internal/cqltext"
+	"strconv"
+	"strings"
+)
+
+type SelectBuilder struct {
+	table             string
+	selectors         []string
+	distinct          bool
+	json              bool
+	where             []Condition
+	groupBy           []string
+	orderBy           []string
+	limit             interface{}
+	perPartitionLimit interface{}
+	allowFiltering    bool
+}
+
+// Starts a SELECT of the given columns of the table (or of all of
+// them if there are none).
+func Select(table string, columns ...string) *SelectBuilder {
+	return &SelectBuilder{table: table, selectors: quoteAll(columns)}
+}
+
+// Selects COUNT(*).
+func (s *SelectBuilder) Count() *SelectBuilder {
+	s.selectors = append(s.selectors, "COUNT(*)")
+	return s
+}
+
+// Selects the remaining time to live of the column, TTL(column).
+func (s *SelectBuilder) TTL(column string) *SelectBuilder {
+	s.selectors = append(s.selectors, "TTL("+cqltext.QuoteIdentifier(column)+")")
+	return s
+}
+
+// Selects the write time of the column, WRITETIME(column).
+func (s *SelectBuilder) WriteTime(column string) *SelectBuilder {
+	s.selectors = append(s.selectors, "WRITETIME("+cqltext.QuoteIdentifier(column)+")")
+	return s
+}
+
+// Selects the result of a function of the given columns (e.g. Fn("max",
+// "price") renders max(price)).
+func (s *SelectBuilder) Fn(name string, columns ...string) *SelectBuilder {
+	s.selectors = append(s.selectors,
+		cqltext.QuoteName(name)+"("+strings.Join(quoteAll(columns), ", ")+")")
+	return s
+}
+
+// Names the last selector, e.g. Fn("max", "price").As("highest").
+func (s *SelectBuilder) As(alias string) *SelectBuilder {
+	if n := len(s.selectors); n > 0 {
+		s.selectors[n-1] += " AS " + cqltext.QuoteIdentifier(alias)
+	}
+	return s
+}
+
+func (s *SelectBuilder) Distinct() *SelectBuilder {
+	s.distinct = true
+	return s
+}
+
+// Returns each row as a single JSON column (SELECT JSON).
+func (s *SelectBuilder) Json() *SelectBuilder {
+	s.json = true
+	return s
+}
+
+// Adds conditions to the WHERE clause.
+func (s *SelectBuilder) Where(conds ...Condition) *SelectBuilder {
+	s.where = append(s.where, conds...)
+	return s
+}
+
+func (s *SelectBuilder) GroupBy(columns ...string) *SelectBuilder {
+	s.groupBy = append(s.groupBy, quoteAll(columns)...)
+	return s
+}
+
+func (s *SelectBuilder) OrderBy(column string, order Order) *SelectBuilder {
+	s.orderBy = append(s.orderBy, cqltext.QuoteIdentifier(column)+" "+order.String())
+	return s
+}
+
+// Limits the number of rows; limit is an int or a Marker.
+func (s *SelectBuilder) Limit(limit interface{}) *SelectBuilder {
+	s.limit = limit
+	return s
+}
+
+// Limits the number of rows per partition; limit is an int or a
+// Marker.
+func (s *SelectBuilder) PerPartitionLimit(limit interface{}) *SelectBuilder {
+	s.perPartitionLimit = limit
+	return s
+}
+
+func (s *SelectBuilder) AllowFiltering() *SelectBuilder {
+	s.allowFiltering = true
+	return s
+}
+
+// writes a limit: integers are rendered and markers bound
+func writeLimit(b *buffer, keyword string, limit interface{}) {
+	if limit == nil {
+		return
+	}
+	b.WriteString(" " + keyword + " ")
+	if n, ok := limit.(int); ok {
+		b.WriteString(strconv.Itoa(n))
+		return
+	}
+	b.bind(limit)
+}
+
+func (s *SelectBuilder) Build() (string, []interface{}) {
+	var b buffer
+	b.WriteString("SELECT ")
+	if s.json {
+		b.WriteString("JSON ")
+	}
+	if s.distinct {
+		b.WriteString("DISTINCT ")
+	}
+	if len(s.selectors) == 0 {
+		b.WriteString("*")
+	} else {
+		b.WriteString(strings.Join(s.selectors, ", "))
+	}
+	b.WriteString(" FROM ")
+	b.WriteString(cqltext.QuoteName(s.table))
+	writeConditions(&b, "WHERE", s.where)
+	if len(s.groupBy) > 0 {
+		b.WriteString(" GROUP BY " + strings.Join(s.groupBy, ", "))
+	}
+	if len(s.orderBy) > 0 {
+		b.WriteString(" ORDER BY " + strings.Join(s.orderBy, ", "))
+	}
+	writeLimit(&b, "PER PARTITION LIMIT", s.perPartitionLimit)
+	writeLimit(&b, "LIMIT", s.limit)
+	if s.allowFiltering {
+		b.WriteString(" ALLOW FILTERING")
+	}
+	return b.build()
+}
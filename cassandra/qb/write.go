@@ -0,0 +1,285 @@
+package qb
+
+import (
+	"golang-driver/cassandra/internal/cqltext"
+	"strings"
+)
+
+type InsertBuilder struct {
+	table       string
+	columns     []string
+	values      []interface{}
+	json        interface{}
+	ifNotExists bool
+	using       using
+}
+
+// Starts an INSERT into the table.
+func Insert(table string) *InsertBuilder {
+	return &InsertBuilder{table: table}
+}
+
+// Sets the value of a column.
+func (i *InsertBuilder) Value(column string, value interface{}) *InsertBuilder {
+	i.columns = append(i.columns, cqltext.QuoteIdentifier(column))
+	i.values = append(i.values, value)
+	return i
+}
+
+// Inserts the row given as a JSON document (INSERT ... JSON ?) instead
+// of the values of the columns.
+func (i *InsertBuilder) Json(doc interface{}) *InsertBuilder {
+	i.json = doc
+	return i
+}
+
+func (i *InsertBuilder) IfNotExists() *InsertBuilder {
+	i.ifNotExists = true
+	return i
+}
+
+// Sets the time to live of the values: a time.Duration (rounded up to
+// a whole second), a number of seconds or a Marker.
+func (i *InsertBuilder) TTL(ttl interface{}) *InsertBuilder {
+	i.using.ttl = ttlValue(ttl)
+	return i
+}
+
+// Sets the write time of the values, in microseconds since Epoch (or a
+// Marker).
+func (i *InsertBuilder) Timestamp(timestamp interface{}) *InsertBuilder {
+	i.using.timestamp = timestamp
+	return i
+}
+
+func (i *InsertBuilder) Build() (string, []interface{}) {
+	var b buffer
+	b.WriteString("INSERT INTO ")
+	b.WriteString(cqltext.QuoteName(i.table))
+	if i.json != nil {
+		b.WriteString(" JSON ")
+		b.bind(i.json)
+	} else {
+		b.WriteString(" (" + strings.Join(i.columns, ", ") + ") VALUES (")
+		b.bindAll(i.values)
+		b.WriteString(")")
+	}
+	if i.ifNotExists {
+		b.WriteString(" IF NOT EXISTS")
+	}
+	i.using.write(&b)
+	return b.build()
+}
+
+type UpdateBuilder struct {
+	table       string
+	assignments []func(b *buffer)
+	where       []Condition
+	ifs         []Condition
+	ifExists    bool
+	using       using
+}
+
+// Starts an UPDATE of the table.
+func Update(table string) *UpdateBuilder {
+	return &UpdateBuilder{table: table}
+}
+
+func (u *UpdateBuilder) assign(assignment func(b *buffer)) *UpdateBuilder {
+	u.assignments = append(u.assignments, assignment)
+	return u
+}
+
+// Sets the value of the column: column = ?.
+func (u *UpdateBuilder) Set(column string, value interface{}) *UpdateBuilder {
+	return u.assign(func(b *buffer) {
+		b.WriteString(cqltext.QuoteIdentifier(column) + " = ")
+		b.bind(value)
+	})
+}
+
+// Sets an element of a list (by index) or of a map (by key):
+// column[?] = ?.
+func (u *UpdateBuilder) SetElement(column string, key, value interface{}) *UpdateBuilder {
+	return u.assign(func(b *buffer) {
+		b.WriteString(cqltext.QuoteIdentifier(column) + "[")
+		b.bind(key)
+		b.WriteString("] = ")
+		b.bind(value)
+	})
+}
+
+// Adds elements to a set or entries to a map: column = column + ?.
+func (u *UpdateBuilder) Add(column string, elements interface{}) *UpdateBuilder {
+	return u.assign(func(b *buffer) {
+		c := cqltext.QuoteIdentifier(column)
+		b.WriteString(c + " = " + c + " + ")
+		b.bind(elements)
+	})
+}
+
+// Removes elements from a set or a list, or keys from a map (given
+// as a set): column = column - ?.
+func (u *UpdateBuilder) Remove(column string, elements interface{}) *UpdateBuilder {
+	return u.assign(func(b *buffer) {
+		c := cqltext.QuoteIdentifier(column)
+		b.WriteString(c + " = " + c + " - ")
+		b.bind(elements)
+	})
+}
+
+// Appends elements to a list: column = column + ?.
+func (u *UpdateBuilder) Append(column string, elements interface{}) *UpdateBuilder {
+	return u.Add(column, elements)
+}
+
+// Prepends elements to a list: column = ? + column.
+func (u *UpdateBuilder) Prepend(column string, elements interface{}) *UpdateBuilder {
+	return u.assign(func(b *buffer) {
+		c := cqltext.QuoteIdentifier(column)
+		b.WriteString(c + " = ")
+		b.bind(elements)
+		b.WriteString(" + " + c)
+	})
+}
+
+// Increments a counter: column = column + ?.
+func (u *UpdateBuilder) Increment(column string, delta interface{}) *UpdateBuilder {
+	return u.Add(column, delta)
+}
+
+// Decrements a counter: column = column - ?.
+func (u *UpdateBuilder) Decrement(column string, delta interface{}) *UpdateBuilder {
+	return u.Remove(column, delta)
+}
+
+// Adds conditions to the WHERE clause.
+func (u *UpdateBuilder) Where(conds ...Condition) *UpdateBuilder {
+	u.where = append(u.where, conds...)
+	return u
+}
+
+// Adds conditions to the IF clause (lightweight transaction).
+func (u *UpdateBuilder) If(conds ...Condition) *UpdateBuilder {
+	u.ifs = append(u.ifs, conds...)
+	return u
+}
+
+func (u *UpdateBuilder) IfExists() *UpdateBuilder {
+	u.ifExists = true
+	return u
+}
+
+// Sets the time to live of the values (see InsertBuilder.TTL).
+func (u *UpdateBuilder) TTL(ttl interface{}) *UpdateBuilder {
+	u.using.ttl = ttlValue(ttl)
+	return u
+}
+
+// Sets the write time of the values (see InsertBuilder.Timestamp).
+func (u *UpdateBuilder) Timestamp(timestamp interface{}) *UpdateBuilder {
+	u.using.timestamp = timestamp
+	return u
+}
+
+func (u *UpdateBuilder) Build() (string, []interface{}) {
+	var b buffer
+	b.WriteString("UPDATE ")
+	b.WriteString(cqltext.QuoteName(u.table))
+	u.using.write(&b)
+	b.WriteString(" SET ")
+	for i, assignment := range u.assignments {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		assignment(&b)
+	}
+	writeConditions(&b, "WHERE", u.where)
+	writeIfs(&b, u.ifs, u.ifExists)
+	return b.build()
+}
+
+func writeIfs(b *buffer, ifs []Condition, ifExists bool) {
+	if ifExists {
+		b.WriteString(" IF EXISTS")
+		return
+	}
+	writeConditions(b, "IF", ifs)
+}
+
+type DeleteBuilder struct {
+	table    string
+	columns  []string
+	elements []interface{}
+	where    []Condition
+	ifs      []Condition
+	ifExists bool
+	using    using
+}
+
+// Starts a DELETE of the given columns of the rows (or of the rows if
+// there are none).
+func Delete(table string, columns ...string) *DeleteBuilder {
+	d := &DeleteBuilder{table: table}
+	for _, c := range columns {
+		d.columns = append(d.columns, cqltext.QuoteIdentifier(c))
+		d.elements = append(d.elements, nil)
+	}
+	return d
+}
+
+// Deletes an element of a list (by index) or of a map (by key):
+// DELETE column[?].
+func (d *DeleteBuilder) Element(column string, key interface{}) *DeleteBuilder {
+	d.columns = append(d.columns, cqltext.QuoteIdentifier(column))
+	d.elements = append(d.elements, key)
+	return d
+}
+
+// Adds conditions to the WHERE clause.
+func (d *DeleteBuilder) Where(conds ...Condition) *DeleteBuilder {
+	d.where = append(d.where, conds...)
+	return d
+}
+
+// Adds conditions to the IF clause (lightweight transaction).
+func (d *DeleteBuilder) If(conds ...Condition) *DeleteBuilder {
+	d.ifs = append(d.ifs, conds...)
+	return d
+}
+
+func (d *DeleteBuilder) IfExists() *DeleteBuilder {
+	d.ifExists = true
+	return d
+}
+
+// Sets the time of the deletion (see InsertBuilder.Timestamp).
+func (d *DeleteBuilder) Timestamp(timestamp interface{}) *DeleteBuilder {
+	d.using.timestamp = timestamp
+	return d
+}
+
+func (d *DeleteBuilder) Build() (string, []interface{}) {
+	var b buffer
+	b.WriteString("DELETE ")
+	for i, c := range d.columns {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(c)
+		if d.elements[i] != nil {
+			b.WriteString("[")
+			b.bind(d.elements[i])
+			b.WriteString("]")
+		}
+	}
+	if len(d.columns) > 0 {
+		b.WriteString(" ")
+	}
+	b.WriteString("FROM ")
+	b.WriteString(cqltext.QuoteName(d.table))
+	d.using.write(&b)
+	writeConditions(&b, "WHERE", d.where)
+	writeIfs(&b, d.ifs, d.ifExists)
+	return b.build()
+}
@@ -0,0 +1,316 @@
+package cassandra
+
+import (
+	"bytes"
+	"cmp"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// A column of a ResultSet.
+type Column struct {
+	Name string
+	Type CassType
+}
+
+// The rows of a result copied into Go values, which don't depend on
+// the memory of the C driver: a ResultSet doesn't need to be closed
+// and can be cached and shared between goroutines (as long as it's not
+// modified, e.g. sorted, concurrently).
+//
+// Each value is the value read into an interface{} (see Row.Value),
+// or nil if it's null.
+type ResultSet struct {
+	Columns []Column
+	Rows    [][]interface{}
+}
+
+// Reads the remaining rows (fetching the next pages if paging is
+// enabled) into a ResultSet and closes the rows.
+func (rows *Rows) Materialize() (*ResultSet, error) {
+	defer rows.Close()
+
+	count := int(rows.ColumnCount())
+	rs := &ResultSet{Columns: make([]Column, count)}
+	for i := range rs.Columns {
+		rs.Columns[i] = Column{rows.ColumnName(i), rows.ColumnType(i)}
+	}
+	for row, err := range rows.All() {
+		if err != nil {
+			return nil, err
+		}
+		values := make([]interface{}, count)
+		for i := range values {
+			if row.IsNull(i) {
+				continue
+			}
+			v, err := row.Value(i)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = v
+		}
+		rs.Rows = append(rs.Rows, values)
+	}
+	return rs, nil
+}
+
+// Returns the number of rows.
+func (rs *ResultSet) Len() int {
+	return len(rs.Rows)
+}
+
+// Returns the index of the column with the given name or -1 if
+// there's none.
+func (rs *ResultSet) ColumnIndex(name string) int {
+	for i, column := range rs.Columns {
+		if column.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Returns the value of the given column of the row at the given index,
+// or nil if it's null or there's no such row or column.
+func (rs *ResultSet) Value(row int, column string) interface{} {
+	index := rs.ColumnIndex(column)
+	if index < 0 || row < 0 || row >= len(rs.Rows) {
+		return nil
+	}
+	return rs.Rows[row][index]
+}
+
+// Stores the values of the row at the given index into the given
+// values, in the order of the columns. The values are converted like
+// the Null* types convert the values they scan, and null values are
+// stored as the zero value.
+func (rs *ResultSet) Scan(row int, dst ...interface{}) error {
+	if row < 0 || row >= len(rs.Rows) {
+		return fmt.Errorf("row index %d out of range", row)
+	}
+	if len(dst) > len(rs.Columns) {
+		return errors.New("invalid argument count")
+	}
+	for i, d := range dst {
+		src := rs.Rows[row][i]
+		if scanner, ok := d.(sql.Scanner); ok {
+			if err := scanner.Scan(src); err != nil {
+				return fmt.Errorf("%s (column: %s)", err.Error(), rs.Columns[i].Name)
+			}
+			continue
+		}
+		dstVal := reflect.ValueOf(d)
+		if dstVal.Kind() != reflect.Ptr || dstVal.IsNil() {
+			return fmt.Errorf("cannot scan into non-pointer %T", d)
+		}
+		target := dstVal.Elem()
+		if src == nil {
+			target.Set(reflect.Zero(target.Type()))
+			continue
+		}
+		if err := convertScanned(src, target); err != nil {
+			return fmt.Errorf("cannot scan %T into %T: %s (column: %s)",
+				src, d, err.Error(), rs.Columns[i].Name)
+		}
+	}
+	return nil
+}
+
+// Sorts the rows with the given function, keeping the order of equal
+// rows.
+func (rs *ResultSet) Sort(less func(a, b []interface{}) bool) {
+	sort.SliceStable(rs.Rows, func(i, j int) bool {
+		return less(rs.Rows[i], rs.Rows[j])
+	})
+}
+
+// Sorts the rows by the values of the given column (nulls first),
+// keeping the order of equal rows. Returns an error, leaving the rows
+// as they are, if there's no such column or its values cannot be
+// compared.
+func (rs *ResultSet) SortBy(column string, descending bool) error {
+	index := rs.ColumnIndex(column)
+	if index < 0 {
+		return fmt.Errorf("no column %s in the result", column)
+	}
+	// the values are all compared with the first one that isn't null
+	// before any row is moved
+	var first interface{}
+	for _, row := range rs.Rows {
+		if row[index] == nil {
+			continue
+		}
+		if first == nil {
+			first = row[index]
+		}
+		if _, err := compareValues(first, row[index]); err != nil {
+			return fmt.Errorf("cannot sort by %s: %s", column, err.Error())
+		}
+	}
+	rs.Sort(func(a, b []interface{}) bool {
+		c, _ := compareValues(a[index], b[index])
+		if descending {
+			return c > 0
+		}
+		return c < 0
+	})
+	return nil
+}
+
+// Encodes the rows as an array of objects with a property per column
+// (in the order of the columns). Sets are encoded as arrays.
+func (rs *ResultSet) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, row := range rs.Rows {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('{')
+		for j, column := range rs.Columns {
+			if j > 0 {
+				buf.WriteByte(',')
+			}
+			name, err := json.Marshal(column.Name)
+			if err != nil {
+				return nil, err
+			}
+			value, err := json.Marshal(jsonValue(row[j], column.Type))
+			if err != nil {
+				return nil, fmt.Errorf("cannot encode column %s: %s", column.Name, err.Error())
+			}
+			buf.Write(name)
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+		buf.WriteByte('}')
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+// returns the elements of a set (read into a map) as a sorted slice
+func jsonValue(v interface{}, cassType CassType) interface{} {
+	rVal := reflect.ValueOf(v)
+	if cassType.primary != CASS_VALUE_TYPE_SET || rVal.Kind() != reflect.Map {
+		return v
+	}
+	elems := make([]interface{}, 0, rVal.Len())
+	for _, key := range rVal.MapKeys() {
+		elems = append(elems, key.Interface())
+	}
+	sort.SliceStable(elems, func(i, j int) bool {
+		c, _ := compareValues(elems[i], elems[j])
+		return c < 0
+	})
+	return elems
+}
+
+// compares the values of a column, nil being less than any other value
+func compareValues(a, b interface{}) (int, error) {
+	switch {
+	case a == nil && b == nil:
+		return 0, nil
+	case a == nil:
+		return -1, nil
+	case b == nil:
+		return 1, nil
+	}
+	switch a := a.(type) {
+	case int8, int16, int32, int64, int:
+		if y, ok := asInt64(b); ok {
+			x, _ := asInt64(a)
+			return cmp.Compare(x, y), nil
+		}
+	case float32, float64:
+		if y, ok := asFloat64(b); ok {
+			x, _ := asFloat64(a)
+			return cmp.Compare(x, y), nil
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return cmp.Compare(a, b), nil
+		}
+	case bool:
+		if b, ok := b.(bool); ok {
+			switch {
+			case a == b:
+				return 0, nil
+			case b:
+				return -1, nil
+			}
+			return 1, nil
+		}
+	case []byte:
+		if b, ok := b.([]byte); ok {
+			return bytes.Compare(a, b), nil
+		}
+	case UUID:
+		if b, ok := b.(UUID); ok {
+			return bytes.Compare(a[:], b[:]), nil
+		}
+	case net.IP:
+		if b, ok := b.(net.IP); ok {
+			return bytes.Compare(a.To16(), b.To16()), nil
+		}
+	case Timestamp:
+		if b, ok := b.(Timestamp); ok {
+			return cmp.Compare(a.Raw(), b.Raw()), nil
+		}
+	case time.Time:
+		if b, ok := b.(time.Time); ok {
+			return a.Compare(b), nil
+		}
+	case Date:
+		if b, ok := b.(Date); ok {
+			return cmp.Compare(a.Raw(), b.Raw()), nil
+		}
+	case Time:
+		if b, ok := b.(Time); ok {
+			return cmp.Compare(a, b), nil
+		}
+	case *Decimal:
+		if b, ok := b.(*Decimal); ok {
+			return a.Cmp(b), nil
+		}
+	case *big.Int:
+		if b, ok := b.(*big.Int); ok {
+			return a.Cmp(b), nil
+		}
+	}
+	return 0, fmt.Errorf("cannot compare %T and %T", a, b)
+}
+
+func asInt64(v interface{}) (int64, bool) {
+	switch v := v.(type) {
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case int:
+		return int64(v), true
+	}
+	return 0, false
+}
+
+func asFloat64(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
@@ -0,0 +1,193 @@
+package cassandra_test
+
+import (
+	"golang-driver/cassandra"
+	"golang-driver/cassandra/test"
+	"testing"
+)
+
+func newTestResultSet() *cassandra.ResultSet {
+	return &cassandra.ResultSet{
+		Columns: []cassandra.Column{
+			{"id", cassandra.CInt},
+			{"name", cassandra.CText},
+			{"price", cassandra.CDecimal},
+			{"tags", cassandra.CSet.Specialize(cassandra.CText)},
+		},
+		Rows: [][]interface{}{
+			{2, "mug", cassandra.NewDecimal(550, 2), map[string]bool{"kitchen": true, "blue": true}},
+			{1, "lamp", cassandra.NewDecimal(1950, 2), nil},
+			{3, nil, nil, map[string]bool{}},
+		},
+	}
+}
+
+func TestResultSet(t *testing.T) {
+	rs := newTestResultSet()
+	if rs.Len() != 3 || rs.ColumnIndex("price") != 2 || rs.ColumnIndex("missing") != -1 {
+		t.Errorf("unexpected result set %+v", rs)
+	}
+	if v := rs.Value(1, "name"); v != "lamp" {
+		t.Errorf("expected lamp, got %v", v)
+	}
+	if v := rs.Value(3, "name"); v != nil {
+		t.Errorf("expected nil for a missing row, got %v", v)
+	}
+	if v := rs.Value(-1, "name"); v != nil {
+		t.Errorf("expected nil for a negative row index, got %v", v)
+	}
+
+	var id int32
+	var name string
+	var price cassandra.NullDecimal
+	if err := rs.Scan(1, &id, &name, &price); err != nil {
+		t.Fatal(err)
+	}
+	if id != 1 || name != "lamp" || !price.Valid || price.Decimal.String() != "19.50" {
+		t.Errorf("unexpected (%d, %s, %+v)", id, name, price)
+	}
+	if err := rs.Scan(2, &id, &name, &price); err != nil {
+		t.Fatal(err)
+	}
+	if id != 3 || name != "" || price.Valid {
+		t.Errorf("unexpected (%d, %s, %+v)", id, name, price)
+	}
+	var i8 int8
+	if err := rs.Scan(0, &i8); err != nil || i8 != 2 {
+		t.Errorf("expected 2, got %d (%v)", i8, err)
+	}
+	if err := rs.Scan(3, &id); err == nil {
+		t.Error("scanning a missing row should be an error")
+	}
+}
+
+func TestResultSetSort(t *testing.T) {
+	rs := newTestResultSet()
+	if err := rs.SortBy("id", false); err != nil {
+		t.Fatal(err)
+	}
+	for i, row := range rs.Rows {
+		if row[0] != i+1 {
+			t.Errorf("expected %d, got %v", i+1, row[0])
+		}
+	}
+	if err := rs.SortBy("price", true); err != nil {
+		t.Fatal(err)
+	}
+	if rs.Rows[0][1] != "lamp" || rs.Rows[1][1] != "mug" || rs.Rows[2][1] != nil {
+		t.Errorf("unexpected order %v", rs.Rows)
+	}
+	if err := rs.SortBy("tags", false); err == nil {
+		t.Error("sets cannot be compared")
+	}
+	if rs.Rows[0][1] != "lamp" || rs.Rows[1][1] != "mug" || rs.Rows[2][1] != nil {
+		t.Errorf("the rows changed on error: %v", rs.Rows)
+	}
+	mixed := &cassandra.ResultSet{
+		Columns: []cassandra.Column{{"v", cassandra.CUnknown}},
+		Rows:    [][]interface{}{{3}, {"b"}, {1}, {nil}, {"a"}},
+	}
+	if err := mixed.SortBy("v", false); err == nil {
+		t.Error("ints and strings cannot be compared")
+	}
+	if mixed.Rows[0][0] != 3 || mixed.Rows[2][0] != 1 || mixed.Rows[4][0] != "a" {
+		t.Errorf("the rows changed on error: %v", mixed.Rows)
+	}
+	if err := rs.SortBy("missing", false); err == nil {
+		t.Error("sorting by a missing column should be an error")
+	}
+	rs.Sort(func(a, b []interface{}) bool {
+		x, _ := a[3].(map[string]bool)
+		y, _ := b[3].(map[string]bool)
+		return len(x) > len(y)
+	})
+	if rs.Rows[0][0] != 2 {
+		t.Errorf("unexpected order %v", rs.Rows)
+	}
+}
+
+func TestResultSetJSON(t *testing.T) {
+	data, err := newTestResultSet().MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `[{"id":2,"name":"mug","price":"5.50","tags":["blue","kitchen"]},` +
+		`{"id":1,"name":"lamp","price":"19.50","tags":null},` +
+		`{"id":3,"name":null,"price":null,"tags":[]}]`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+}
+
+func TestMaterialize(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	if err := test.Setup(materializeSetup); err != nil {
+		t.Log("Unexpected error while setup. You might need to clean up manually golang_driver keyspace")
+		t.Fatal(err)
+	}
+	defer test.TearDown(materializeCleanup)
+
+	stmt, err := session.Query("SELECT seq, name FROM golang_driver.materialized WHERE bucket = ?", int32(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+	rows, err := stmt.WithPagingSize(2).Exec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs, err := rows.Materialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rs.Len() != 5 || len(rs.Columns) != 2 || rs.Columns[1].Name != "name" || !rs.Columns[1].Type.Equals(cassandra.CText) {
+		t.Fatalf("unexpected result set %+v", rs)
+	}
+	if rs.Value(4, "name") != nil || rs.Value(0, "name") != "a" {
+		t.Errorf("unexpected values %v", rs.Rows)
+	}
+
+	// executing the statement again starts from the first page
+	rows, err = stmt.Exec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := rows.Materialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Len() != 5 || again.Value(0, "seq") != 0 {
+		t.Errorf("unexpected values %v of the second execution", again.Rows)
+	}
+
+	// the result set is safe to use from other goroutines
+	done := make(chan error)
+	go func() {
+		done <- rs.SortBy("seq", true)
+	}()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	var seq int
+	if err := rs.Scan(0, &seq); err != nil || seq != 4 {
+		t.Errorf("expected 4, got %d (%v)", seq, err)
+	}
+}
+
+var (
+	materializeSetup = []string{
+		"CREATE KEYSPACE IF NOT EXISTS golang_driver WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};",
+		`CREATE TABLE IF NOT EXISTS golang_driver.materialized(bucket int, seq int, name text, PRIMARY KEY (bucket, seq))`,
+		"INSERT INTO golang_driver.materialized (bucket, seq, name) VALUES (1, 0, 'a')",
+		"INSERT INTO golang_driver.materialized (bucket, seq, name) VALUES (1, 1, 'b')",
+		"INSERT INTO golang_driver.materialized (bucket, seq, name) VALUES (1, 2, 'c')",
+		"INSERT INTO golang_driver.materialized (bucket, seq, name) VALUES (1, 3, 'd')",
+		"INSERT INTO golang_driver.materialized (bucket, seq) VALUES (1, 4)",
+	}
+
+	materializeCleanup = []string{
+		"DROP TABLE golang_driver.materialized",
+	}
+)
@@ -0,0 +1,139 @@
+package cassandra
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Marks the bound values at the given positions as the components
+// of the partition key. Together with the keyspace (see WithKeyspace)
+// this allows the C/C++ driver to compute the routing key of simple
+// statements and route them to a replica. Prepared statements get this
+// information from the server and don't need it.
+//
+// The positions must be listed in the order of the partition key columns.
+func (stmt *Statement) WithPartitionKey(indexes ...int) *Statement {
+	stmt.keyIndexes = append(stmt.keyIndexes, indexes...)
+	return stmt
+}
+
+// Marks the bound values equal to the given values as the components
+// of the partition key (see WithPartitionKey). The values must be
+// listed in the order of the partition key columns and each of them
+// must be one of the values bound to the statement.
+func (stmt *Statement) WithRoutingKey(values ...interface{}) *Statement {
+	used := make(map[int]bool)
+	for _, v := range values {
+		found := false
+		for i, arg := range stmt.Args {
+			if !used[i] && reflect.DeepEqual(arg, v) {
+				used[i] = true
+				stmt.keyIndexes = append(stmt.keyIndexes, i)
+				found = true
+				break
+			}
+		}
+		if !found && stmt.err == nil {
+			stmt.err = fmt.Errorf("routing key value %v (%T) is not bound to the statement", v, v)
+		}
+	}
+	return stmt
+}
+
+// Returns the serialized routing key computed from the bound values
+// marked as partition key components. Composite keys use the
+// Cassandra composite format: for each component a 2 byte length,
+// the value and a 0 byte.
+func (stmt *Statement) RoutingKey() ([]byte, error) {
+	if stmt.err != nil {
+		return nil, stmt.err
+	}
+	if len(stmt.keyIndexes) == 0 {
+		return nil, nil
+	}
+	components := make([][]byte, len(stmt.keyIndexes))
+	for i, idx := range stmt.keyIndexes {
+		if idx < 0 || idx >= len(stmt.Args) {
+			return nil, fmt.Errorf("partition key index %d out of range (%d values bound)",
+				idx, len(stmt.Args))
+		}
+		buf, err := marshalRoutingComponent(stmt.Args[idx], stmt.dataType(idx))
+		if err != nil {
+			return nil, err
+		}
+		components[i] = buf
+	}
+	return encodeRoutingKey(components), nil
+}
+
+func encodeRoutingKey(components [][]byte) []byte {
+	if len(components) == 1 {
+		return components[0]
+	}
+	var buf bytes.Buffer
+	for _, c := range components {
+		binary.Write(&buf, binary.BigEndian, uint16(len(c)))
+		buf.Write(c)
+		buf.WriteByte(0)
+	}
+	return buf.Bytes()
+}
+
+// Serializes a value the way it's sent to Cassandra. Only the types
+// that can be part of a partition key are supported.
+func marshalRoutingComponent(value interface{}, dataType CassType) ([]byte, error) {
+	if value == nil {
+		return nil, fmt.Errorf("partition key components cannot be null")
+	}
+	tv, err := newCassTypedVal(value, dataType)
+	if err != nil {
+		return nil, err
+	}
+	defer tv.Free()
+
+	ptv, ok := tv.(*primitiveTypedVal)
+	if !ok {
+		kind := tv.Kind()
+		return nil, fmt.Errorf("%s values cannot be used in routing keys", kind.String())
+	}
+
+	buf := new(bytes.Buffer)
+	switch ptv.kind.primary {
+	case CASS_VALUE_TYPE_ASCII, CASS_VALUE_TYPE_TEXT, CASS_VALUE_TYPE_VARCHAR:
+		buf.WriteString(ptv.val.(string))
+	case CASS_VALUE_TYPE_BOOLEAN:
+		buf.WriteByte(byte(ptv.val.(int)))
+	case CASS_VALUE_TYPE_BIGINT, CASS_VALUE_TYPE_COUNTER,
+		CASS_VALUE_TYPE_TIMESTAMP, CASS_VALUE_TYPE_TIME:
+		binary.Write(buf, binary.BigEndian, reflect.ValueOf(ptv.val).Int())
+	case CASS_VALUE_TYPE_INT:
+		binary.Write(buf, binary.BigEndian, int32(reflect.ValueOf(ptv.val).Int()))
+	case CASS_VALUE_TYPE_SMALL_INT:
+		binary.Write(buf, binary.BigEndian, int16(reflect.ValueOf(ptv.val).Int()))
+	case CASS_VALUE_TYPE_TINY_INT:
+		binary.Write(buf, binary.BigEndian, int8(reflect.ValueOf(ptv.val).Int()))
+	case CASS_VALUE_TYPE_FLOAT:
+		f := float32(reflect.ValueOf(ptv.val).Float())
+		binary.Write(buf, binary.BigEndian, math.Float32bits(f))
+	case CASS_VALUE_TYPE_DOUBLE:
+		f := reflect.ValueOf(ptv.val).Float()
+		binary.Write(buf, binary.BigEndian, math.Float64bits(f))
+	case CASS_VALUE_TYPE_DECIMAL:
+		dec := ptv.val.(*Decimal)
+		binary.Write(buf, binary.BigEndian, dec.Scale)
+		buf.Write(export2Complement(dec.Value))
+	case CASS_VALUE_TYPE_DATE:
+		binary.Write(buf, binary.BigEndian, uint32(reflect.ValueOf(ptv.val).Uint()))
+	case CASS_VALUE_TYPE_UUID, CASS_VALUE_TYPE_TIMEUUID:
+		u := ptv.val.(UUID)
+		buf.Write(u[:])
+	case CASS_VALUE_TYPE_BLOB, CASS_VALUE_TYPE_VARINT, CASS_VALUE_TYPE_INET:
+		buf.Write(ptv.val.([]byte))
+	default:
+		return nil, fmt.Errorf("%s values cannot be used in routing keys", ptv.kind.String())
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,101 @@
+package cassandra_test
+
+import (
+	"bytes"
+	"golang-driver/cassandra/test"
+	"testing"
+)
+
+func TestSingleRoutingKey(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	stmt, err := session.Query("SELECT * FROM golang_driver.routing WHERE id = ?", int32(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+
+	key, err := stmt.WithPartitionKey(0).RoutingKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{0, 0, 0, 42}
+	if !bytes.Equal(key, expected) {
+		t.Errorf("routing key %v != %v", key, expected)
+	}
+}
+
+func TestCompositeRoutingKey(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	stmt, err := session.Query("INSERT INTO golang_driver.routing (id, name, value) VALUES (?, ?, ?)",
+		int32(1), "ab", "value")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+
+	key, err := stmt.WithRoutingKey(int32(1), "ab").RoutingKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{
+		0, 4, 0, 0, 0, 1, 0,
+		0, 2, 'a', 'b', 0,
+	}
+	if !bytes.Equal(key, expected) {
+		t.Errorf("routing key %v != %v", key, expected)
+	}
+}
+
+func TestRoutingKeyNotBound(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	stmt, err := session.Query("SELECT * FROM golang_driver.routing WHERE id = ?", int32(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.WithRoutingKey(int32(43)).RoutingKey(); err == nil {
+		t.Error("a value not bound to the statement cannot be part of the routing key")
+	} else {
+		t.Log(err)
+	}
+}
+
+func TestTokenAwareSimpleStatement(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	if err := test.Setup(routingSetup); err != nil {
+		t.Log("Unexpected error while setup. You might need to clean up manually golang_driver keyspace")
+		t.Fatal(err)
+	}
+	defer test.TearDown(routingCleanup)
+
+	stmt, err := session.Query("INSERT INTO golang_driver.routing (id, name, value) VALUES (?, ?, ?)",
+		int32(2), "routed", "value")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.WithKeyspace("golang_driver").WithPartitionKey(0, 1).Exec(); err != nil {
+		t.Error(err)
+	}
+}
+
+var (
+	routingSetup = []string{
+		"CREATE KEYSPACE IF NOT EXISTS golang_driver WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};",
+		"CREATE TABLE IF NOT EXISTS golang_driver.routing (id int, name text, value text, PRIMARY KEY ((id, name)))",
+	}
+
+	routingCleanup = []string{
+		"DROP TABLE golang_driver.routing",
+	}
+)
@@ -0,0 +1,203 @@
+package cassandra
+
+// #cgo LDFLAGS: -L/usr/local/lib -lcassandra
+// #cgo CFLAGS: -I/usr/local/include
+// #include <stdlib.h>
+// #include <cassandra.h>
+import "C"
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+)
+
+// The current row of Rows. A Row is only valid until the next call to
+// Rows.Next (or until the Rows are closed).
+//
+// The typed accessors read the column at the given index (or with the
+// given name) in the same way as Rows.Scan with a pointer to a value of
+// the type; null values are read as the zero value.
+type Row struct {
+	rows *Rows
+	cptr *C.CassRow
+}
+
+// Returns an iterator over the remaining rows. The iteration stops
+// after an error, e.g. a failure to fetch the next page, which is also
+// reported by Err.
+//
+//	for row, err := range rows.All() {
+//		if err != nil {
+//			return err
+//		}
+//		name, err := row.StringByName("name")
+//		...
+//	}
+func (rows *Rows) All() iter.Seq2[*Row, error] {
+	return func(yield func(*Row, error) bool) {
+		for rows.Next() {
+			if !yield(rows.row(), nil) {
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+func (rows *Rows) row() *Row {
+	return &Row{rows, C.cass_iterator_get_row(rows.iter)}
+}
+
+// Returns the index of the column with the given name or -1 if
+// there's none.
+func (rows *Rows) ColumnIndex(name string) int {
+	if rows.columns == nil {
+		count := int(rows.ColumnCount())
+		rows.columns = make(map[string]int, count)
+		for i := count - 1; i >= 0; i-- {
+			rows.columns[rows.ColumnName(i)] = i
+		}
+	}
+	if index, ok := rows.columns[name]; ok {
+		return index
+	}
+	return -1
+}
+
+// Reads the columns into the given values, in the order of the columns
+// (see Rows.Scan).
+func (row *Row) Scan(args ...interface{}) error {
+	if row.rows.ColumnCount() < uint64(len(args)) {
+		return errors.New("invalid argument count")
+	}
+	for i, v := range args {
+		if err := row.Get(i, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reads the column at the given index into dst.
+func (row *Row) Get(index int, dst interface{}) error {
+	if index < 0 || uint64(index) >= row.rows.ColumnCount() {
+		return fmt.Errorf("column index %d out of range", index)
+	}
+	pos := C.size_t(index)
+	value := C.cass_row_get_column(row.cptr, pos)
+	ctype := cassTypeFromCassDataType(
+		C.cass_result_column_data_type(row.rows.cptr, pos))
+
+	if _, err := read(value, ctype, dst); err != nil {
+		return newColumnError(row.rows, index, dst, err)
+	}
+	return nil
+}
+
+// Reads the column with the given name into dst.
+func (row *Row) GetByName(name string, dst interface{}) error {
+	index := row.rows.ColumnIndex(name)
+	if index < 0 {
+		return fmt.Errorf("no column %s in the result", name)
+	}
+	return row.Get(index, dst)
+}
+
+// Returns true if the value of the column at the given index is null.
+func (row *Row) IsNull(index int) bool {
+	if index < 0 || uint64(index) >= row.rows.ColumnCount() {
+		return true
+	}
+	return isNull(C.cass_row_get_column(row.cptr, C.size_t(index)))
+}
+
+// returns the value of the column as a T
+func column[T any](row *Row, index int) (T, error) {
+	var v T
+	err := row.Get(index, &v)
+	return v, err
+}
+
+func columnByName[T any](row *Row, name string) (T, error) {
+	var v T
+	err := row.GetByName(name, &v)
+	return v, err
+}
+
+// Returns the value of the column as read into an interface{} (e.g.
+// an int for an `int` column or a *Decimal for a `decimal` column).
+func (row *Row) Value(index int) (interface{}, error) {
+	return column[interface{}](row, index)
+}
+
+func (row *Row) ValueByName(name string) (interface{}, error) {
+	return columnByName[interface{}](row, name)
+}
+
+func (row *Row) Bool(index int) (bool, error) {
+	return column[bool](row, index)
+}
+
+func (row *Row) BoolByName(name string) (bool, error) {
+	return columnByName[bool](row, name)
+}
+
+func (row *Row) Int32(index int) (int32, error) {
+	return column[int32](row, index)
+}
+
+func (row *Row) Int32ByName(name string) (int32, error) {
+	return columnByName[int32](row, name)
+}
+
+func (row *Row) Int64(index int) (int64, error) {
+	return column[int64](row, index)
+}
+
+func (row *Row) Int64ByName(name string) (int64, error) {
+	return columnByName[int64](row, name)
+}
+
+func (row *Row) Float64(index int) (float64, error) {
+	return column[float64](row, index)
+}
+
+func (row *Row) Float64ByName(name string) (float64, error) {
+	return columnByName[float64](row, name)
+}
+
+func (row *Row) String(index int) (string, error) {
+	return column[string](row, index)
+}
+
+func (row *Row) StringByName(name string) (string, error) {
+	return columnByName[string](row, name)
+}
+
+func (row *Row) Bytes(index int) ([]byte, error) {
+	return column[[]byte](row, index)
+}
+
+func (row *Row) BytesByName(name string) ([]byte, error) {
+	return columnByName[[]byte](row, name)
+}
+
+func (row *Row) UUID(index int) (UUID, error) {
+	return column[UUID](row, index)
+}
+
+func (row *Row) UUIDByName(name string) (UUID, error) {
+	return columnByName[UUID](row, name)
+}
+
+// Returns the value of a `timestamp` or `date` column.
+func (row *Row) Time(index int) (time.Time, error) {
+	return column[time.Time](row, index)
+}
+
+func (row *Row) TimeByName(name string) (time.Time, error) {
+	return columnByName[time.Time](row, name)
+}
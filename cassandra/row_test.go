@@ -0,0 +1,126 @@
+package cassandra_test
+
+import (
+	"golang-driver/cassandra/test"
+	"testing"
+)
+
+func TestRowsAll(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	if err := test.Setup(rowsSetup); err != nil {
+		t.Log("Unexpected error while setup. You might need to clean up manually golang_driver keyspace")
+		t.Fatal(err)
+	}
+	defer test.TearDown(rowsCleanup)
+
+	insert, err := session.Prepare("INSERT INTO golang_driver.events (bucket, seq, name) VALUES (?, ?, ?)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer insert.Close()
+	for i := 0; i < 25; i++ {
+		var name interface{}
+		if i%5 != 0 {
+			name = "event"
+		}
+		if _, err := insert.Exec(int32(1), int32(i), name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the statement is closed before the rows are iterated: the rows
+	// keep it to fetch the next pages
+	stmt, err := session.Query("SELECT seq, name FROM golang_driver.events WHERE bucket = ?", int32(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := stmt.WithPagingSize(10).Exec()
+	stmt.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	if rows.ColumnIndex("name") != 1 || rows.ColumnIndex("missing") != -1 {
+		t.Errorf("unexpected column indexes")
+	}
+	count := 0
+	for row, err := range rows.All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		seq, err := row.Int32(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seq != int32(count) {
+			t.Errorf("expected %d, got %d", count, seq)
+		}
+		name, err := row.StringByName("name")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (seq%5 == 0) != row.IsNull(1) || (seq%5 != 0 && name != "event") {
+			t.Errorf("unexpected name %q for %d", name, seq)
+		}
+		if v, err := row.ValueByName("seq"); err != nil || v != int(seq) {
+			t.Errorf("expected %d, got %v (%v)", seq, v, err)
+		}
+		if _, err := row.StringByName("missing"); err == nil {
+			t.Error("reading a missing column should be an error")
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if count != 25 {
+		t.Errorf("expected 25 rows over 3 pages, got %d", count)
+	}
+
+	// prepared statements, stopping early
+	pstmt, err := session.Prepare("SELECT seq FROM golang_driver.events WHERE bucket = ?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pstmt.Close()
+	pstmt.SetPagingSize(4)
+	prows, err := pstmt.Exec(int32(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer prows.Close()
+	count = 0
+	for row, err := range prows.All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seq, err := row.Int32(0); err != nil || seq != int32(count) {
+			t.Errorf("expected %d, got %d (%v)", count, seq, err)
+		}
+		count++
+		if count == 10 {
+			break
+		}
+	}
+	// the iteration can be resumed
+	for prows.Next() {
+		count++
+	}
+	if count != 25 || prows.Err() != nil {
+		t.Errorf("expected 25 rows, got %d (%v)", count, prows.Err())
+	}
+}
+
+var (
+	rowsSetup = []string{
+		"CREATE KEYSPACE IF NOT EXISTS golang_driver WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};",
+		`CREATE TABLE IF NOT EXISTS golang_driver.events(bucket int, seq int, name text, PRIMARY KEY (bucket, seq))`,
+	}
+
+	rowsCleanup = []string{
+		"DROP TABLE golang_driver.events",
+	}
+)
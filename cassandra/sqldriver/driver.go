@@ -0,0 +1,473 @@
+// Package sqldriver implements a database/sql driver on top of the
+// cassandra package. It's registered as "cassandra":
+//
+//	db, err := sql.Open("cassandra", "cassandra://127.0.0.1/shop?consistency=quorum")
+//
+// See ParseDSN for the format of the data source names. The connections
+// of a sql.DB share a single session, which is closed by sql.DB.Close.
+// Values are bound with the conversions of the cassandra package, so
+// its types (e.g. cassandra.UUID) can be used directly. The values of
+// the rows are driver values (see cassandra.DriverValue): e.g. uuid
+// columns are scanned as strings and timestamps as time.Time, and the
+// cassandra.Null* types scan them back into the types of the package.
+// Named arguments (sql.Named) are bound to the markers with the same
+// name (e.g. :id) or to the columns positional markers are bound to.
+// Transactions are not supported.
+package sqldriver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"golang-driver/cassandra"
+	"io"
+	"math/big"
+	"net"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+func init() {
+	sql.Register("cassandra", &Driver{})
+}
+
+var errNoTransactions = errors.New("cassandra: transactions are not supported")
+
+type Driver struct{}
+
+// Opens a connection with its own session, which is closed with the
+// connection. database/sql uses OpenConnector instead.
+func (d *Driver) Open(dsn string) (driver.Conn, error) {
+	connector, err := d.OpenConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	c, err := connector.Connect(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	c.(*conn).owner = connector.(*Connector)
+	return c, nil
+}
+
+func (d *Driver) OpenConnector(dsn string) (driver.Connector, error) {
+	config, err := ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return NewConnector(config), nil
+}
+
+// A Connector creates connections sharing a session, which is opened
+// with the first connection.
+type Connector struct {
+	sync.Mutex
+	config  *Config
+	session *cassandra.Session
+}
+
+// Creates a connector to use with sql.OpenDB, e.g. when the
+// configuration isn't given as a data source name.
+func NewConnector(config *Config) *Connector {
+	return &Connector{config: config}
+}
+
+func (c *Connector) Connect(ctx context.Context) (driver.Conn, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	session, err := c.getSession()
+	if err != nil {
+		return nil, err
+	}
+	return &conn{session: session, config: c.config}, nil
+}
+
+func (c *Connector) Driver() driver.Driver {
+	return &Driver{}
+}
+
+// Closes the session shared by the connections (called by sql.DB.Close).
+func (c *Connector) Close() error {
+	c.Lock()
+	defer c.Unlock()
+	if c.session != nil {
+		cluster := c.session.Cluster
+		c.session.Close()
+		cluster.Close()
+		c.session = nil
+	}
+	return nil
+}
+
+func (c *Connector) getSession() (*cassandra.Session, error) {
+	c.Lock()
+	defer c.Unlock()
+	if c.session != nil {
+		return c.session, nil
+	}
+	cluster, err := c.config.newCluster()
+	if err != nil {
+		return nil, err
+	}
+	var session *cassandra.Session
+	if c.config.Keyspace != "" {
+		session, err = cluster.ConnectKeyspace(c.config.Keyspace)
+	} else {
+		session, err = cluster.Connect()
+	}
+	if err != nil {
+		cluster.Close()
+		return nil, err
+	}
+	c.session = session
+	return session, nil
+}
+
+type conn struct {
+	session *cassandra.Session
+	config  *Config
+	// set for the connections opened by Driver.Open
+	owner *Connector
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	pstmt, err := c.session.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	if c.config.Consistency != 0 {
+		pstmt.SetConsistency(c.config.Consistency)
+	}
+	if c.config.SerialConsistency != 0 {
+		pstmt.SetSerialConsistency(c.config.SerialConsistency)
+	}
+	return &stmt{pstmt: pstmt, names: pstmt.ParameterNames()}, nil
+}
+
+func (c *conn) Close() error {
+	if c.owner != nil {
+		return c.owner.Close()
+	}
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return nil, errNoTransactions
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return nil, errNoTransactions
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+	rows, err := c.exec(ctx, "SELECT release_version FROM system.local", nil)
+	if err != nil {
+		return err
+	}
+	rows.Close()
+	return nil
+}
+
+// Accepts all the values: they're converted when they're bound.
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	return nil
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	cassRows, err := c.exec(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	return newRows(cassRows), nil
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	cassRows, err := c.exec(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	cassRows.Close()
+	return result{}, nil
+}
+
+// executes a simple statement, or prepares the query when the
+// arguments are named
+func (c *conn) exec(ctx context.Context, query string, args []driver.NamedValue) (*cassandra.Rows, error) {
+	for _, arg := range args {
+		if arg.Name != "" {
+			s, err := c.PrepareContext(ctx, query)
+			if err != nil {
+				return nil, err
+			}
+			defer s.Close()
+			return s.(*stmt).exec(ctx, args)
+		}
+	}
+
+	values := make([]interface{}, len(args))
+	for _, arg := range args {
+		values[arg.Ordinal-1] = arg.Value
+	}
+	st, err := c.session.Query(query, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer st.Close()
+	if c.config.Consistency != 0 {
+		st.WithConsistency(c.config.Consistency)
+	}
+	if c.config.SerialConsistency != 0 {
+		st.WithSerialConsistency(c.config.SerialConsistency)
+	}
+	return execute(ctx, st)
+}
+
+type stmt struct {
+	pstmt *cassandra.PreparedStatement
+	names []string
+}
+
+func (s *stmt) Close() error {
+	s.pstmt.Close()
+	return nil
+}
+
+// The markers can be repeated when they're named, so the arguments
+// are checked when they're bound.
+func (s *stmt) NumInput() int {
+	return -1
+}
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), namedValues(args))
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), namedValues(args))
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	cassRows, err := s.exec(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	cassRows.Close()
+	return result{}, nil
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	cassRows, err := s.exec(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return newRows(cassRows), nil
+}
+
+func (s *stmt) exec(ctx context.Context, args []driver.NamedValue) (*cassandra.Rows, error) {
+	values, err := s.values(args)
+	if err != nil {
+		return nil, err
+	}
+	st, err := s.pstmt.Query(values...)
+	if err != nil {
+		return nil, err
+	}
+	defer st.Close()
+	return execute(ctx, st)
+}
+
+// orders the arguments as the markers of the statement
+func (s *stmt) values(args []driver.NamedValue) ([]interface{}, error) {
+	named := 0
+	for _, arg := range args {
+		if arg.Name != "" {
+			named++
+		}
+	}
+	if named == 0 {
+		if len(args) != len(s.names) {
+			return nil, fmt.Errorf("cassandra: expected %d arguments, got %d", len(s.names), len(args))
+		}
+		values := make([]interface{}, len(args))
+		for _, arg := range args {
+			values[arg.Ordinal-1] = arg.Value
+		}
+		return values, nil
+	}
+	if named != len(args) {
+		return nil, errors.New("cassandra: cannot mix named and positional arguments")
+	}
+
+	values := make([]interface{}, len(s.names))
+	used := make([]bool, len(args))
+	for i, name := range s.names {
+		found := false
+		for j, arg := range args {
+			if strings.EqualFold(arg.Name, name) {
+				values[i], used[j], found = arg.Value, true, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("cassandra: missing argument %q", name)
+		}
+	}
+	for j, arg := range args {
+		if !used[j] {
+			return nil, fmt.Errorf("cassandra: unknown argument %q", arg.Name)
+		}
+	}
+	return values, nil
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, v := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+	return named
+}
+
+// executes the statement, giving up when the context is done; the
+// deadline of the context becomes the request timeout
+func execute(ctx context.Context, st *cassandra.Statement) (*cassandra.Rows, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout := time.Until(deadline)
+		if timeout < time.Millisecond {
+			return nil, context.DeadlineExceeded
+		}
+		st.WithRequestTimeout(timeout)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	future := st.ExecAsync()
+	if ctx.Done() == nil {
+		defer future.Close()
+		if err := future.Error(); err != nil {
+			return nil, err
+		}
+		return future.Result(), nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- future.Error()
+	}()
+	select {
+	case err := <-done:
+		defer future.Close()
+		if err != nil {
+			return nil, err
+		}
+		return future.Result(), nil
+	case <-ctx.Done():
+		// the future is freed once the request completes
+		go func() {
+			<-done
+			future.Close()
+		}()
+		return nil, ctx.Err()
+	}
+}
+
+type rows struct {
+	rows    *cassandra.Rows
+	columns []string
+}
+
+func newRows(cassRows *cassandra.Rows) *rows {
+	columns := make([]string, cassRows.ColumnCount())
+	for i := range columns {
+		columns[i] = cassRows.ColumnName(i)
+	}
+	return &rows{cassRows, columns}
+}
+
+func (r *rows) Columns() []string {
+	return r.columns
+}
+
+func (r *rows) Close() error {
+	r.rows.Close()
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if !r.rows.Next() {
+		if err := r.rows.Err(); err != nil {
+			return err
+		}
+		return io.EOF
+	}
+	values := make([]interface{}, len(dest))
+	ptrs := make([]interface{}, len(dest))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	if err := r.rows.Scan(ptrs...); err != nil {
+		return err
+	}
+	for i, v := range values {
+		dest[i] = cassandra.DriverValue(v)
+	}
+	return nil
+}
+
+// Returns the CQL type of the column, e.g. text or list<int>.
+func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.rows.ColumnType(index).String()
+}
+
+// Returns the Go type of the driver values of the column (see
+// cassandra.DriverValue).
+func (r *rows) ColumnTypeScanType(index int) reflect.Type {
+	t, err := r.rows.ColumnType(index).GoType()
+	if err != nil {
+		return reflect.TypeOf((*interface{})(nil)).Elem()
+	}
+	return driverType(t)
+}
+
+// the type of the driver values of the values of type t
+func driverType(t reflect.Type) reflect.Type {
+	switch t {
+	case reflect.TypeOf(cassandra.Timestamp{}), reflect.TypeOf(cassandra.Date{}):
+		return reflect.TypeOf(time.Time{})
+	case reflect.TypeOf(cassandra.Time(0)), reflect.TypeOf(cassandra.UUID{}),
+		reflect.TypeOf(new(cassandra.Decimal)), reflect.TypeOf(new(big.Int)),
+		reflect.TypeOf(net.IP{}), reflect.TypeOf(cassandra.Duration{}):
+		return reflect.TypeOf("")
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return reflect.TypeOf(int64(0))
+	case reflect.Float32:
+		return reflect.TypeOf(float64(0))
+	}
+	return t
+}
+
+// Cassandra doesn't return the number of affected rows.
+type result struct{}
+
+func (result) LastInsertId() (int64, error) {
+	return 0, errors.New("cassandra: LastInsertId is not supported")
+}
+
+func (result) RowsAffected() (int64, error) {
+	return 0, errors.New("cassandra: RowsAffected is not supported")
+}
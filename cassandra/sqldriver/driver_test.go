@@ -0,0 +1,153 @@
+package sqldriver_test
+
+import (
+	"context"
+	"database/sql"
+	"golang-driver/cassandra"
+	_ "golang-driver/cassandra/sqldriver"
+	"golang-driver/cassandra/test"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDatabaseSQL(t *testing.T) {
+	test.GetSession()
+	defer test.Shutdown()
+
+	if err := test.Setup(sqlSetup); err != nil {
+		t.Log("Unexpected error while setup. You might need to clean up manually golang_driver keyspace")
+		t.Fatal(err)
+	}
+	defer test.TearDown(sqlCleanup)
+
+	db, err := sql.Open("cassandra", "127.0.0.1/golang_driver?consistency=one&timeout=10s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	id := cassandra.NewRandomUUID()
+	added := time.Date(2016, 2, 29, 10, 11, 39, 0, time.UTC)
+	day, err := cassandra.NewDate(2016, 3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.ExecContext(ctx, "INSERT INTO products (id, name, price, tags, added, available, stock) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		id, "lamp", 19.5, []string{"home"}, added, day, int32(12)); err != nil {
+		t.Fatal(err)
+	}
+	id2 := cassandra.NewRandomUUID()
+	if _, err := db.ExecContext(ctx, "INSERT INTO products (id, name, price) VALUES (:id, :name, :price)",
+		sql.Named("price", 5.0), sql.Named("name", "mug"), sql.Named("id", id2)); err != nil {
+		t.Fatal(err)
+	}
+
+	stmt, err := db.PrepareContext(ctx, "SELECT name, price, tags FROM products WHERE id = ?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+
+	var name string
+	var price float64
+	var tags []string
+	if err := stmt.QueryRowContext(ctx, id).Scan(&name, &price, &tags); err != nil {
+		t.Fatal(err)
+	}
+	if name != "lamp" || price != 19.5 || !reflect.DeepEqual(tags, []string{"home"}) {
+		t.Errorf("expected (lamp, 19.5, [home]), got (%s, %v, %v)", name, price, tags)
+	}
+	var nullTags interface{}
+	if err := stmt.QueryRowContext(ctx, id2).Scan(&name, &price, &nullTags); err != nil {
+		t.Fatal(err)
+	}
+	if name != "mug" || price != 5 || nullTags != nil {
+		t.Errorf("expected (mug, 5, nil), got (%s, %v, %v)", name, price, nullTags)
+	}
+
+	// timestamps and dates are scanned as times, ints as int64
+	var addedAt time.Time
+	var available sql.NullTime
+	var stock interface{}
+	var nullAdded sql.NullTime
+	if err := db.QueryRowContext(ctx, "SELECT added, available, stock FROM products WHERE id = ?", id).
+		Scan(&addedAt, &available, &stock); err != nil {
+		t.Fatal(err)
+	}
+	if !addedAt.Equal(added) {
+		t.Errorf("%s != %s (expected)", addedAt, added)
+	}
+	if !available.Valid || !available.Time.Equal(time.Date(2016, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected date %v", available)
+	}
+	if stock != int64(12) {
+		t.Errorf("expected int64 12, got %T %v", stock, stock)
+	}
+	if err := db.QueryRowContext(ctx, "SELECT added FROM products WHERE id = ?", id2).Scan(&nullAdded); err != nil {
+		t.Fatal(err)
+	} else if nullAdded.Valid {
+		t.Errorf("expected a null timestamp, got %v", nullAdded.Time)
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT id, name, price, tags FROM products")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"uuid", "text", "double", "list<text>"}
+	for i, ct := range types {
+		if ct.DatabaseTypeName() != expected[i] {
+			t.Errorf("expected %s, got %s", expected[i], ct.DatabaseTypeName())
+		}
+	}
+	if st := types[0].ScanType(); st != reflect.TypeOf("") {
+		t.Errorf("expected string, got %s", st)
+	}
+	count := 0
+	for rows.Next() {
+		var rid cassandra.NullUUID
+		var rname string
+		var rprice float64
+		var rtags []string
+		if err := rows.Scan(&rid, &rname, &rprice, &rtags); err != nil {
+			t.Fatal(err)
+		}
+		if !rid.Valid || (rid.UUID != id && rid.UUID != id2) {
+			t.Errorf("unexpected id %v", rid)
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 rows, got %d", count)
+	}
+
+	if _, err := db.Begin(); err == nil {
+		t.Error("transactions should not be supported")
+	}
+}
+
+var (
+	sqlSetup = []string{
+		"CREATE KEYSPACE IF NOT EXISTS golang_driver WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};",
+		`CREATE TABLE IF NOT EXISTS golang_driver.products(id uuid PRIMARY KEY, name text, price double, tags list<text>,
+			added timestamp, available date, stock int)`,
+	}
+
+	sqlCleanup = []string{
+		"DROP TABLE golang_driver.products",
+	}
+)
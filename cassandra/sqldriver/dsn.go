@@ -0,0 +1,172 @@
+package sqldriver
+
+import (
+	"fmt"
+	"golang-driver/cassandra"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The connection settings parsed from a data source name.
+type Config struct {
+	// the contact points
+	Hosts []string
+	// the port of all the nodes (0 for the default, 9042)
+	Port int
+	// the keyspace used by the statements (optional)
+	Keyspace string
+	// the consistency levels of the statements (0 for the defaults)
+	Consistency       cassandra.Consistency
+	SerialConsistency cassandra.Consistency
+	// the cluster-wide timeouts (0 for the defaults)
+	ConnectTimeout time.Duration
+	RequestTimeout time.Duration
+	// the native protocol version (0 to negotiate it)
+	ProtocolVersion uint8
+}
+
+var consistencies = map[string]cassandra.Consistency{
+	"any":          cassandra.ANY,
+	"one":          cassandra.ONE,
+	"two":          cassandra.TWO,
+	"three":        cassandra.THREE,
+	"quorum":       cassandra.QUORUM,
+	"all":          cassandra.ALL,
+	"local_quorum": cassandra.LOCAL_QUORUM,
+	"each_quorum":  cassandra.EACH_QUORUM,
+	"serial":       cassandra.SERIAL,
+	"local_serial": cassandra.LOCAL_SERIAL,
+	"local_one":    cassandra.LOCAL_ONE,
+}
+
+// Parses a data source name of the form
+// [cassandra://]host1[:port][,host2[:port]...][/keyspace][?param=value&...],
+// e.g. cassandra://10.0.0.1,10.0.0.2:9042/shop?consistency=local_quorum.
+// All the hosts must use the same port. IPv6 addresses with a port are
+// written in brackets ([::1]:9042). The parameters are:
+// * consistency, serial_consistency: a consistency level (e.g. quorum)
+// * timeout, connect_timeout: the request and connection timeouts (e.g. 5s)
+// * protocol_version: the native protocol version
+func ParseDSN(dsn string) (*Config, error) {
+	s := strings.TrimPrefix(dsn, "cassandra://")
+	var query string
+	if i := strings.IndexByte(s, '?'); i >= 0 {
+		s, query = s[:i], s[i+1:]
+	}
+	config := new(Config)
+	if i := strings.IndexByte(s, '/'); i >= 0 {
+		s, config.Keyspace = s[:i], s[i+1:]
+		if strings.Contains(config.Keyspace, "/") {
+			return nil, fmt.Errorf("invalid keyspace %q in %q", config.Keyspace, dsn)
+		}
+	}
+	if strings.Contains(s, "@") {
+		return nil, fmt.Errorf("credentials in %q are not supported", dsn)
+	}
+	if s == "" {
+		return nil, fmt.Errorf("missing hosts in %q", dsn)
+	}
+	for _, hostport := range strings.Split(s, ",") {
+		host, port, err := splitHostPort(hostport)
+		if err != nil {
+			return nil, fmt.Errorf("invalid host %q in %q: %s", hostport, dsn, err.Error())
+		}
+		if port != 0 {
+			if config.Port != 0 && config.Port != port {
+				return nil, fmt.Errorf("all the hosts in %q must use the same port", dsn)
+			}
+			config.Port = port
+		}
+		config.Hosts = append(config.Hosts, host)
+	}
+
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters in %q: %s", dsn, err.Error())
+	}
+	for name, values := range params {
+		value := values[len(values)-1]
+		if err := config.setParam(name, value); err != nil {
+			return nil, fmt.Errorf("invalid %s in %q: %s", name, dsn, err.Error())
+		}
+	}
+	return config, nil
+}
+
+func (config *Config) setParam(name, value string) error {
+	var err error
+	switch name {
+	case "consistency":
+		config.Consistency, err = parseConsistency(value)
+	case "serial_consistency":
+		config.SerialConsistency, err = parseConsistency(value)
+	case "timeout":
+		config.RequestTimeout, err = time.ParseDuration(value)
+	case "connect_timeout":
+		config.ConnectTimeout, err = time.ParseDuration(value)
+	case "protocol_version":
+		var v uint64
+		v, err = strconv.ParseUint(value, 10, 8)
+		if err == nil && v == 0 {
+			err = fmt.Errorf("must be at least 1")
+		}
+		config.ProtocolVersion = uint8(v)
+	default:
+		err = fmt.Errorf("unknown parameter")
+	}
+	return err
+}
+
+func parseConsistency(s string) (cassandra.Consistency, error) {
+	if c, ok := consistencies[strings.ToLower(s)]; ok {
+		return c, nil
+	}
+	return 0, fmt.Errorf("unknown consistency %q", s)
+}
+
+// returns the host and port (0 if none)
+func splitHostPort(hostport string) (string, int, error) {
+	if hostport == "" {
+		return "", 0, fmt.Errorf("empty host")
+	}
+	if strings.HasPrefix(hostport, "[") && strings.HasSuffix(hostport, "]") {
+		return hostport[1 : len(hostport)-1], 0, nil
+	}
+	// bare IPv6 addresses have more than one colon
+	if !strings.HasPrefix(hostport, "[") && strings.Count(hostport, ":") != 1 {
+		return hostport, 0, nil
+	}
+	host, portStr, err := net.SplitHostPort(hostport)
+	if err != nil {
+		return "", 0, err
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port <= 0 || port > 65535 {
+		return "", 0, fmt.Errorf("invalid port %q", portStr)
+	}
+	return host, port, nil
+}
+
+// Creates a cluster with the settings of the configuration.
+func (config *Config) newCluster() (*cassandra.Cluster, error) {
+	cluster := cassandra.NewCluster(config.Hosts...)
+	if config.Port != 0 {
+		if err := cluster.SetPort(config.Port); err != nil {
+			cluster.Close()
+			return nil, err
+		}
+	}
+	if config.ProtocolVersion != 0 {
+		cluster.SetProtocolVersion(config.ProtocolVersion)
+	}
+	if config.ConnectTimeout != 0 {
+		cluster.SetConnectionTimeout(config.ConnectTimeout)
+	}
+	if config.RequestTimeout != 0 {
+		cluster.SetRequestTimeout(config.RequestTimeout)
+	}
+	return cluster, nil
+}
@@ -0,0 +1,60 @@
+package sqldriver_test
+
+import (
+	"golang-driver/cassandra"
+	"golang-driver/cassandra/sqldriver"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseDSN(t *testing.T) {
+	cases := []struct {
+		dsn      string
+		expected sqldriver.Config
+	}{
+		{"127.0.0.1", sqldriver.Config{Hosts: []string{"127.0.0.1"}}},
+		{"cassandra://10.0.0.1,10.0.0.2:9043/shop", sqldriver.Config{
+			Hosts: []string{"10.0.0.1", "10.0.0.2"}, Port: 9043, Keyspace: "shop"}},
+		{"[::1]:9042,::2,[::3]", sqldriver.Config{Hosts: []string{"::1", "::2", "::3"}, Port: 9042}},
+		{"db1/ks?consistency=LOCAL_QUORUM&serial_consistency=local_serial&timeout=5s&connect_timeout=500ms&protocol_version=4",
+			sqldriver.Config{
+				Hosts:             []string{"db1"},
+				Keyspace:          "ks",
+				Consistency:       cassandra.LOCAL_QUORUM,
+				SerialConsistency: cassandra.LOCAL_SERIAL,
+				RequestTimeout:    5 * time.Second,
+				ConnectTimeout:    500 * time.Millisecond,
+				ProtocolVersion:   4,
+			}},
+	}
+	for _, c := range cases {
+		config, err := sqldriver.ParseDSN(c.dsn)
+		if err != nil {
+			t.Errorf("%s: %s", c.dsn, err)
+			continue
+		}
+		if !reflect.DeepEqual(*config, c.expected) {
+			t.Errorf("%s: expected %+v, got %+v", c.dsn, c.expected, *config)
+		}
+	}
+}
+
+func TestParseInvalidDSN(t *testing.T) {
+	for _, dsn := range []string{
+		"",
+		"cassandra:///ks",
+		"user:pass@127.0.0.1",
+		"h1:9042,h2:9043",
+		"h1:port",
+		"h1/ks/table",
+		"h1?consistency=most",
+		"h1?timeout=5",
+		"h1?protocol_version=0",
+		"h1?unknown=1",
+	} {
+		if config, err := sqldriver.ParseDSN(dsn); err == nil {
+			t.Errorf("%q should not be a valid DSN (got %+v)", dsn, *config)
+		}
+	}
+}
@@ -7,6 +7,11 @@ package cassandra
 import "C"
 import (
 	"fmt"
+	"golang-driver/cassandra/internal/cqltext"
+	"net"
+	"strings"
+	"sync"
+	"time"
 	"unsafe"
 )
 
@@ -14,9 +19,29 @@ type Statement struct {
 	cptr              *C.struct_CassStatement_
 	session           *Session
 	pstmt             *PreparedStatement
+	query             string
 	consistency       Consistency
 	serialConsistency Consistency
+	customPayload     map[string][]byte
+	tracing           bool
+	requestTimeout    time.Duration
+	// whether requestTimeout overrides the cluster-wide timeout
+	requestTimeoutSet bool
+	keyspace          string
+	host              net.IP
+	port              int
+	keyIndexes        []int
+	appliedKeyIndexes int
+	pagingSize        int
+	err               error
 	Args              []interface{}
+	// guards the C statement, whose paging state is changed by the Rows
+	// paging through its results, possibly from other goroutines
+	mu sync.Mutex
+	// set by Close; the statement is freed once the Rows paging
+	// through its results are closed too
+	closed bool
+	pagers int
 }
 
 func (stmt *Statement) WithConsistency(c Consistency) *Statement {
@@ -29,13 +54,88 @@ func (stmt *Statement) WithSerialConsistency(c Consistency) *Statement {
 	return stmt
 }
 
+// Attaches a custom payload to the request. The payload is made
+// available to server side query handlers (e.g. triggers, audit plugins)
+// and requires protocol version 4 or newer.
+func (stmt *Statement) WithCustomPayload(payload map[string][]byte) *Statement {
+	stmt.customPayload = payload
+	return stmt
+}
+
+// Enables (or disables) query tracing for this statement. The trace
+// session id is available through Rows.TracingID() and the trace can
+// be retrieved with Session.FetchTrace().
+func (stmt *Statement) WithTracing(enabled bool) *Statement {
+	stmt.tracing = enabled
+	return stmt
+}
+
+// Overrides the cluster-wide request timeout (see Cluster.SetRequestTimeout)
+// for this statement. A timeout of 0 disables the timeout; a negative
+// one is an error returned when the statement is executed.
+func (stmt *Statement) WithRequestTimeout(timeout time.Duration) *Statement {
+	if timeout < 0 {
+		if stmt.err == nil {
+			stmt.err = fmt.Errorf("invalid request timeout %s", timeout)
+		}
+		return stmt
+	}
+	stmt.requestTimeout = timeout
+	stmt.requestTimeoutSet = true
+	return stmt
+}
+
+// Sets the keyspace the statement is executed against. This is used
+// by token-aware routing and, with protocol v5, sent with the request.
+func (stmt *Statement) WithKeyspace(keyspace string) *Statement {
+	stmt.keyspace = keyspace
+	return stmt
+}
+
+// Pins the statement to the node with the given address and port,
+// bypassing the load balancing policy. The request fails if the node
+// is not available.
+func (stmt *Statement) WithHost(ip net.IP, port int) *Statement {
+	stmt.host = ip
+	stmt.port = port
+	return stmt
+}
+
 // func (stmt *Statement) WithTimestamp(ts int) *Statement          {}
-// func (stmt *Statement) WithCustomPayload(payload int) *Statement {}
+
+// Sets the number of rows fetched per page. The next pages are
+// fetched by Rows.Next as the rows are iterated. Each execution of the
+// statement starts from the first page.
+func (stmt *Statement) WithPagingSize(size int) *Statement {
+	stmt.pagingSize = size
+	return stmt
+}
+
 // func (stmt *Statement) WithPagingToken(token int) *Statement     {}
 
 func (stmt *Statement) Close() {
-	C.cass_statement_free(stmt.cptr)
-	stmt.cptr = nil
+	stmt.mu.Lock()
+	defer stmt.mu.Unlock()
+	stmt.closed = true
+	stmt.release()
+}
+
+// called when a Future or Rows that fetched pages with the statement is
+// closed
+func (stmt *Statement) donePaging() {
+	stmt.mu.Lock()
+	defer stmt.mu.Unlock()
+	stmt.pagers--
+	stmt.release()
+}
+
+// frees the statement once it's closed and no Rows fetch pages with it;
+// stmt.mu must be held
+func (stmt *Statement) release() {
+	if stmt.closed && stmt.pagers == 0 && stmt.cptr != nil {
+		C.cass_statement_free(stmt.cptr)
+		stmt.cptr = nil
+	}
 }
 
 func (stmt *Statement) Exec() (*Rows, error) {
@@ -49,28 +149,143 @@ func (stmt *Statement) Exec() (*Rows, error) {
 }
 
 func (stmt *Statement) ExecAsync() *Future {
+	stmt.mu.Lock()
+	defer stmt.mu.Unlock()
+	if err := stmt.applyOptions(); err != nil {
+		// return an error Future
+		return &Future{err: err}
+	}
+	// a previous execution may have left the paging state of its last
+	// page fetched
+	var empty C.char
+	if retc := C.cass_statement_set_paging_state_token(stmt.cptr, &empty, 0); retc != C.CASS_OK {
+		return &Future{err: newError(retc)}
+	}
+
+	warnings := inflight.track(stmt.session)
+	future := async(func() *C.struct_CassFuture_ {
+		return C.cass_session_execute(stmt.session.cptr, stmt.cptr)
+	})
+	future.session = stmt.session
+	future.warnings = warnings
+	if keyspace, ok := usedKeyspace(stmt.query); ok {
+		future.usedKeyspace = &keyspace
+	}
+	if stmt.pagingSize > 0 {
+		// the Rows need the statement to fetch the next pages
+		future.stmt = stmt
+		stmt.pagers++
+	}
+
+	return future
+}
+
+// executes the statement for the page following the result and waits
+// for the response, so that the paging state isn't changed meanwhile
+func (stmt *Statement) execNextPage(result *C.struct_CassResult_) (*Future, error) {
+	stmt.mu.Lock()
+	defer stmt.mu.Unlock()
+	if retc := C.cass_statement_set_paging_state(stmt.cptr, result); retc != C.CASS_OK {
+		return nil, newError(retc)
+	}
+	future := async(func() *C.struct_CassFuture_ {
+		return C.cass_session_execute(stmt.session.cptr, stmt.cptr)
+	})
+	if err := future.Error(); err != nil {
+		future.Close()
+		return nil, err
+	}
+	return future, nil
+}
+
+// stmt.mu must be held
+func (stmt *Statement) applyOptions() error {
+	if stmt.err != nil {
+		return stmt.err
+	}
 	if stmt.consistency != unset {
 		retc := C.cass_statement_set_consistency(stmt.cptr, stmt.consistency.toC())
 		if retc != C.CASS_OK {
-			// return an error Future
-			return &Future{err: newError(retc)}
+			return newError(retc)
 		}
 	}
 	if stmt.serialConsistency != unset {
 		retc := C.cass_statement_set_serial_consistency(stmt.cptr, stmt.serialConsistency.toC())
 		if retc != C.CASS_OK {
-			// return an error Future
-			return &Future{err: newError(retc)}
+			return newError(retc)
 		}
 	}
-
-	return async(func() *C.struct_CassFuture_ {
-		return C.cass_session_execute(stmt.session.cptr, stmt.cptr)
-	})
+	if stmt.tracing {
+		retc := C.cass_statement_set_tracing(stmt.cptr, C.cass_true)
+		if retc != C.CASS_OK {
+			return newError(retc)
+		}
+	}
+	if len(stmt.customPayload) > 0 {
+		payload := newCustomPayload(stmt.customPayload)
+		// the statement keeps its own reference to the payload
+		defer C.cass_custom_payload_free(payload)
+		retc := C.cass_statement_set_custom_payload(stmt.cptr, payload)
+		if retc != C.CASS_OK {
+			return newError(retc)
+		}
+	}
+	if stmt.requestTimeoutSet {
+		// the timeout is in milliseconds: a shorter one is rounded up,
+		// as 0 disables the timeout
+		timeout := stmt.requestTimeout / time.Millisecond
+		if stmt.requestTimeout%time.Millisecond > 0 {
+			timeout++
+		}
+		retc := C.cass_statement_set_request_timeout(stmt.cptr,
+			C.cass_uint64_t(timeout))
+		if retc != C.CASS_OK {
+			return newError(retc)
+		}
+	}
+	if stmt.keyspace != "" {
+		cKeyspace := C.CString(stmt.keyspace)
+		defer C.free(unsafe.Pointer(cKeyspace))
+		retc := C.cass_statement_set_keyspace(stmt.cptr, cKeyspace)
+		if retc != C.CASS_OK {
+			return newError(retc)
+		}
+	}
+	if stmt.pagingSize > 0 {
+		retc := C.cass_statement_set_paging_size(stmt.cptr, C.int(stmt.pagingSize))
+		if retc != C.CASS_OK {
+			return newError(retc)
+		}
+	}
+	if stmt.host != nil {
+		ip := stmt.host
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+		}
+		var cInet C.struct_CassInet_
+		cInet.address_length = C.cass_uint8_t(len(ip))
+		for i := range ip {
+			cInet.address[i] = C.cass_uint8_t(ip[i])
+		}
+		retc := C.cass_statement_set_host_inet(stmt.cptr, &cInet, C.int(stmt.port))
+		if retc != C.CASS_OK {
+			return newError(retc)
+		}
+	}
+	// prepared statements carry their own routing information
+	if stmt.pstmt == nil {
+		for _, idx := range stmt.keyIndexes[stmt.appliedKeyIndexes:] {
+			retc := C.cass_statement_add_key_index(stmt.cptr, C.size_t(idx))
+			if retc != C.CASS_OK {
+				return newError(retc)
+			}
+		}
+		stmt.appliedKeyIndexes = len(stmt.keyIndexes)
+	}
+	return nil
 }
 
 func (stmt *Statement) bind(args ...interface{}) error {
-	fmt.Printf("statement.bind(%v)\n", args)
 	stmt.Args = args
 	for i, v := range args {
 		if err := write(stmt, v, i, stmt.dataType(i)); err != nil {
@@ -80,6 +295,62 @@ func (stmt *Statement) bind(args ...interface{}) error {
 	return nil
 }
 
+// Returns the query of the statement with its bind markers replaced
+// by the literals of the bound values (see CassType.Literal), e.g. to
+// log the statement or to print it instead of executing it. Named
+// markers are replaced in order, like positional ones.
+func (stmt *Statement) Interpolate() (string, error) {
+	var b strings.Builder
+	query := stmt.query
+	arg := 0
+	for i := 0; i < len(query); {
+		if j := cqltext.Skip(query, i); j > i {
+			b.WriteString(query[i:j])
+			i = j
+			continue
+		}
+		end := i + 1
+		switch {
+		case query[i] == '?':
+		case query[i] == ':' && (i == 0 || !isIdentifierByte(query[i-1])) &&
+			end < len(query) && (query[end] == '"' || isLetter(query[end])):
+			// a named marker, e.g. :id or :"userId"
+			if query[end] == '"' {
+				end = cqltext.Skip(query, end)
+			}
+			for end < len(query) && isIdentifierByte(query[end]) {
+				end++
+			}
+		default:
+			b.WriteByte(query[i])
+			i++
+			continue
+		}
+		if arg >= len(stmt.Args) {
+			return "", fmt.Errorf("the statement has more bind markers than its %d values", len(stmt.Args))
+		}
+		literal, err := stmt.dataType(arg).Literal(stmt.Args[arg])
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(literal)
+		arg++
+		i = end
+	}
+	if arg < len(stmt.Args) {
+		return "", fmt.Errorf("the statement has %d bind markers but %d values", arg, len(stmt.Args))
+	}
+	return b.String(), nil
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentifierByte(c byte) bool {
+	return isLetter(c) || isDigit(c) || c == '_'
+}
+
 func (stmt *Statement) dataType(index int) CassType {
 	if stmt.pstmt == nil {
 		return CUnknown
@@ -89,6 +360,21 @@ func (stmt *Statement) dataType(index int) CassType {
 			C.size_t(index))))
 }
 
+func newCustomPayload(payload map[string][]byte) *C.struct_CassCustomPayload_ {
+	cptr := C.cass_custom_payload_new()
+	for name, value := range payload {
+		cName := C.CString(name)
+		var cValue *C.cass_byte_t
+		if len(value) > 0 {
+			cValue = (*C.cass_byte_t)(unsafe.Pointer(&value[0]))
+		}
+		C.cass_custom_payload_set_n(cptr, cName, C.size_t(len(name)),
+			cValue, C.size_t(len(value)))
+		C.free(unsafe.Pointer(cName))
+	}
+	return cptr
+}
+
 func newSimpleStatement(session *Session, query string, paramLen int) *Statement {
 	cQuery := C.CString(query)
 	defer C.free(unsafe.Pointer(cQuery))
@@ -96,6 +382,7 @@ func newSimpleStatement(session *Session, query string, paramLen int) *Statement
 	stmt := new(Statement)
 	stmt.cptr = C.cass_statement_new(cQuery, C.size_t(paramLen))
 	stmt.session = session
+	stmt.query = query
 	stmt.consistency = unset
 	stmt.serialConsistency = unset
 
@@ -106,6 +393,7 @@ func newBoundStatement(pstmt *PreparedStatement) *Statement {
 	stmt := new(Statement)
 	stmt.cptr = C.cass_prepared_bind(pstmt.cptr)
 	stmt.pstmt = pstmt
+	stmt.query = pstmt.query
 	stmt.session = pstmt.session
 	stmt.consistency = unset
 	stmt.serialConsistency = unset
@@ -0,0 +1,106 @@
+package cassandra_test
+
+import (
+	"golang-driver/cassandra"
+	"golang-driver/cassandra/test"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestStatementRequestTimeout(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	stmt, err := session.Query("select key from system.local")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.WithRequestTimeout(5 * time.Second).Exec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	test.IterateRows(rows, t)
+
+	invalid, err := session.Query("select key from system.local")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer invalid.Close()
+	if _, err := invalid.WithRequestTimeout(-time.Second).Exec(); err == nil {
+		t.Error("a negative request timeout should be an error")
+	}
+}
+
+func TestStatementKeyspace(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	// the keyspace is used only for routing with protocol versions < 5
+	stmt, err := session.Query("select key from system.local")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+	if rows, err := stmt.WithKeyspace("system").Exec(); err != nil {
+		t.Error(err)
+	} else {
+		rows.Close()
+	}
+
+	// with protocol v5 (Cassandra 4.0+), an unqualified table is read
+	// from the keyspace sent with the request
+	cluster := cassandra.NewCluster("127.0.0.1")
+	defer cluster.Close()
+	cluster.SetProtocolVersion(5)
+	v5, err := cluster.Connect()
+	if err != nil {
+		t.Skipf("protocol v5 is not supported: %s", err.Error())
+	}
+	defer v5.Close()
+
+	unqualified, err := v5.Query("select key from local")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unqualified.Close()
+	rows, err := unqualified.WithKeyspace("system").Exec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		t.Fatal("expected the row of system.local")
+	}
+	var key string
+	if err := rows.Scan(&key); err != nil {
+		t.Fatal(err)
+	} else if key != "local" {
+		t.Errorf("%s != local (expected)", key)
+	}
+}
+
+func TestStatementHost(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	stmt, err := session.Query("select key from system.local")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+
+	host := net.ParseIP("127.0.0.1")
+	rows, err := stmt.WithHost(host, 9042).Exec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	if coordinator := rows.Coordinator(); coordinator != nil && !coordinator.Equal(host) {
+		t.Errorf("request pinned to %s was coordinated by %s", host, coordinator)
+	}
+}
@@ -46,6 +46,74 @@ func TestTime(t *testing.T) {
 	}
 }
 
+func TestParseTime(t *testing.T) {
+	cases := map[string]string{
+		"00:00:00":           "00:00:00.000000000",
+		"08:30:00.5":         "08:30:00.500000000",
+		"23:59:59.999999999": "23:59:59.999999999",
+		"7:05:09.01":         "07:05:09.010000000",
+	}
+	for str, expected := range cases {
+		tm, err := cassandra.ParseTime(str)
+		if err != nil {
+			t.Errorf("%s: %s", str, err)
+			continue
+		}
+		if tm.String() != expected {
+			t.Errorf("%s: expected %s, got %s", str, expected, tm.String())
+		}
+	}
+	for _, str := range []string{"", "24:00:00", "10:60:00", "10:00:60", "10:00", "10:00:00.",
+		"10:00:00.1234567891", "10:-1:00", "a:00:00", "10:00:00.1e"} {
+		if tm, err := cassandra.ParseTime(str); err == nil {
+			t.Errorf("%q should not be a valid time (got %s)", str, tm)
+		}
+	}
+}
+
+func TestTimeArithmetic(t *testing.T) {
+	tm, err := cassandra.TimeFromDuration(13*time.Hour + 5*time.Minute + 7*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tm.String() != "13:05:00.007000000" || tm.Format(3) != "13:05:00.007" || tm.Format(0) != "13:05:00" {
+		t.Errorf("unexpected formats %s %s %s", tm.String(), tm.Format(3), tm.Format(0))
+	}
+	if tm.Duration() != 13*time.Hour+5*time.Minute+7*time.Millisecond {
+		t.Errorf("unexpected duration %s", tm.Duration())
+	}
+	if _, err := cassandra.TimeFromDuration(24 * time.Hour); err == nil {
+		t.Error("24h should not be a valid time")
+	}
+	if _, err := cassandra.TimeFromDuration(-time.Nanosecond); err == nil {
+		t.Error("a negative duration should not be a valid time")
+	}
+
+	later, err := tm.Add(10*time.Hour + 54*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if later.String() != "23:59:00.007000000" || later.Sub(tm) != 10*time.Hour+54*time.Minute || !later.After(tm) {
+		t.Errorf("unexpected %s", later)
+	}
+	if _, err := later.Add(time.Minute); err == nil {
+		t.Error("adding a minute to 23:59:00.007 should wrap around")
+	}
+	if _, err := tm.Add(-14 * time.Hour); err == nil {
+		t.Error("subtracting 14h from 13:05 should wrap around")
+	}
+
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	at := time.Date(2016, 2, 29, 23, 30, 15, 250, loc)
+	if tm := cassandra.TimeOf(at); tm.String() != "23:30:15.000000250" {
+		t.Errorf("expected 23:30:15.000000250, got %s", tm)
+	}
+	on := tm.On(at)
+	if !on.Equal(time.Date(2016, 2, 29, 13, 5, 0, 7000000, loc)) || on.Location() != loc {
+		t.Errorf("unexpected %s", on)
+	}
+}
+
 func TestTimestamp(t *testing.T) {
 	ts1 := cassandra.NewTimestamp(1450606299)
 	ts2 := cassandra.NewTimestampFromTime(time.Date(2015, 12, 20, 10, 11, 39, 0, time.UTC))
@@ -56,15 +124,24 @@ func TestTimestamp(t *testing.T) {
 	}
 }
 
+// returns the date, which must be in the range of the date type
+func mustDate(year int, month time.Month, day int) cassandra.Date {
+	d, err := cassandra.NewDate(year, month, day)
+	if err != nil {
+		panic(err.Error())
+	}
+	return d
+}
+
 func TestDate(t *testing.T) {
-	d1 := cassandra.NewDate(2015, 12, 20)
+	d1 := mustDate(2015, 12, 20)
 	tDay := time.Date(2015, 12, 20, 0, 0, 0, 0, time.UTC)
 
 	if !tDay.Equal(d1.Time()) {
 		t.Errorf("%s != %s", tDay, d1.Time())
 	}
 
-	d2 := cassandra.NewDate(1970, 1, 1)
+	d2 := mustDate(1970, 1, 1)
 	if float64(d2.Raw()) != math.Pow(2, 31) {
 		t.Errorf("Center %d != %d", math.Pow(2, 31), d2.Raw())
 	}
@@ -82,6 +159,99 @@ func TestDate(t *testing.T) {
 	}
 }
 
+func TestDateRange(t *testing.T) {
+	cases := []struct {
+		str string
+		raw uint32
+	}{
+		{"1970-01-01", 1 << 31},
+		{"1969-12-31", 1<<31 - 1},
+		{"1600-02-29", 1<<31 - 135081},
+		{"-0044-03-15", 1<<31 - 735525},
+		{"+10000-01-01", 1<<31 + 2932897},
+		{"-5877641-06-23", 0},
+		{"+5881580-07-11", math.MaxUint32},
+	}
+	for _, c := range cases {
+		d, err := cassandra.ParseDate(c.str)
+		if err != nil {
+			t.Errorf("%s: %s", c.str, err)
+			continue
+		}
+		if d.Raw() != c.raw || d.String() != c.str {
+			t.Errorf("%s: expected %d, got %d (%s)", c.str, c.raw, d.Raw(), d)
+		}
+	}
+	for _, str := range []string{"", "2015-13-01", "2015-02-29", "2016-02-30", "2016-00-10",
+		"2016-01-00", "+5881580-07-12", "-5877641-06-22", "2016/01/01", "2016-1-1-1", "--2016-01-01"} {
+		if d, err := cassandra.ParseDate(str); err == nil {
+			t.Errorf("%q should not be a valid date (got %s)", str, d)
+		}
+	}
+
+	old := mustDate(1200, 5, 3)
+	if y, m, d := old.Date(); y != 1200 || m != time.May || d != 3 {
+		t.Errorf("expected 1200-05-03, got %d-%d-%d", y, m, d)
+	}
+	if !old.Time().Equal(time.Date(1200, 5, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected %s", old.Time())
+	}
+	if d := mustDate(2015, 10, 32); d.String() != "2015-11-01" {
+		t.Errorf("expected 2015-11-01, got %s", d)
+	}
+	if d := mustDate(2016, 0, 1); d.String() != "2015-12-01" {
+		t.Errorf("expected 2015-12-01, got %s", d)
+	}
+	if d, err := cassandra.NewDate(5881580, 7, 12); err == nil {
+		t.Errorf("expected an error for a date after the range, got %s", d)
+	}
+	if d, err := cassandra.NewDate(-5877641, 6, 22); err == nil {
+		t.Errorf("expected an error for a date before the range, got %s", d)
+	}
+}
+
+func TestDateArithmetic(t *testing.T) {
+	d := mustDate(2016, 2, 28)
+	next, err := d.AddDays(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.String() != "2016-03-01" || next.Sub(d) != 2 || d.Sub(next) != -2 || !next.After(d) || !d.Before(next) {
+		t.Errorf("unexpected %s", next)
+	}
+	if d.Weekday() != time.Sunday || mustDate(1, 1, 1).Weekday() != time.Monday ||
+		mustDate(-1, 12, 31).Weekday() != time.Friday {
+		t.Errorf("unexpected weekdays")
+	}
+	back, err := d.AddDays(-800000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.String() != "-0175-11-01" || back.Weekday() != time.Tuesday {
+		t.Errorf("unexpected %s (%s)", back, back.Weekday())
+	}
+	max, _ := cassandra.ParseDate("+5881580-07-11")
+	if _, err := max.AddDays(1); err == nil {
+		t.Error("adding a day to the last date should fail")
+	}
+
+	loc := time.FixedZone("UTC-5", -5*60*60)
+	late := time.Date(2016, 2, 29, 22, 0, 0, 0, loc)
+	if d, err := cassandra.DateOf(late); err != nil || d.String() != "2016-02-29" {
+		t.Errorf("expected 2016-02-29, got %s (%v)", d, err)
+	}
+	if d, err := cassandra.DateOf(late.UTC()); err != nil || d.String() != "2016-03-01" {
+		t.Errorf("expected 2016-03-01, got %s (%v)", d, err)
+	}
+	// time.Time spans a much wider range than the date type
+	if d, err := cassandra.DateOf(time.Date(10000000, 1, 1, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Errorf("expected an error for a date out of the range, got %s", d)
+	}
+	if in := mustDate(2016, 2, 29).In(loc); !in.Equal(time.Date(2016, 2, 29, 0, 0, 0, 0, loc)) || in.Location() != loc {
+		t.Errorf("unexpected %s", in)
+	}
+}
+
 func TestTimeTypes(t *testing.T) {
 	session := test.GetSession()
 	defer test.Shutdown()
@@ -134,6 +304,28 @@ func TestTimeTypes(t *testing.T) {
 	if tsAsInt64 != 1450606299 {
 		t.Errorf("Timestamp 47234000000 != %d", tsAsInt64)
 	}
+
+	// dates far from Epoch, bound as time.Time
+	archived := time.Date(1215, 6, 15, 18, 0, 0, 0, time.FixedZone("UTC+1", 60*60))
+	if _, err := session.Exec("INSERT INTO golang_driver.timetypes (id, td) VALUES (?, ?)",
+		int32(2), archived); err != nil {
+		t.Fatal(err)
+	}
+	rows2, err := session.Exec("SELECT td, td FROM golang_driver.timetypes WHERE id = ?", int32(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows2.Close()
+	if !rows2.Next() {
+		t.Fatal("there must be a result")
+	}
+	var asTime time.Time
+	if err := rows2.Scan(&td, &asTime); err != nil {
+		t.Fatal(err)
+	}
+	if td.String() != "1215-06-15" || !asTime.Equal(time.Date(1215, 6, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected 1215-06-15, got %s (%s)", td, asTime)
+	}
 }
 
 var (
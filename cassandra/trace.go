@@ -0,0 +1,135 @@
+package cassandra
+
+import (
+	"errors"
+	"net"
+	"time"
+)
+
+// Returned by Session.FetchTrace if the trace session didn't
+// complete within the allowed number of attempts.
+var ErrTraceIncomplete = errors.New("trace session is not yet complete")
+
+const (
+	traceMaxAttempts   = 5
+	traceRetryInterval = 3 * time.Millisecond
+)
+
+// A Trace holds the details of a query trace session as recorded
+// by Cassandra in the system_traces keyspace.
+type Trace struct {
+	ID          UUID
+	Coordinator net.IP
+	Request     string
+	Parameters  map[string]string
+	StartedAt   Timestamp
+	// Duration of the request in microseconds as measured by the coordinator
+	Duration int32
+	Events   []TraceEvent
+}
+
+// A TraceEvent is a single step recorded during a trace session.
+type TraceEvent struct {
+	ID       UUID
+	Activity string
+	Source   net.IP
+	// Microseconds elapsed on the source host since the start of the request
+	SourceElapsed int32
+	Thread        string
+}
+
+// Returns the distinct hosts that recorded events for this trace.
+func (trace *Trace) Sources() []net.IP {
+	seen := make(map[string]bool)
+	var sources []net.IP
+	for _, e := range trace.Events {
+		if e.Source == nil || seen[e.Source.String()] {
+			continue
+		}
+		seen[e.Source.String()] = true
+		sources = append(sources, e.Source)
+	}
+	return sources
+}
+
+// Retrieves the trace session with the given id (see Rows.TracingID()).
+// Traces are written asynchronously by Cassandra, so this polls
+// system_traces.sessions until the session is complete and then
+// reads all its events. It returns ErrTraceIncomplete if the session
+// didn't complete in time.
+func (session *Session) FetchTrace(id UUID) (*Trace, error) {
+	wait := traceRetryInterval
+	for attempt := 0; attempt < traceMaxAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(wait)
+			wait *= 2
+		}
+		trace, err := session.fetchTraceSession(id)
+		if err != nil {
+			return nil, err
+		}
+		if trace == nil {
+			continue
+		}
+		if err := session.fetchTraceEvents(trace); err != nil {
+			return nil, err
+		}
+		return trace, nil
+	}
+	return nil, ErrTraceIncomplete
+}
+
+// returns nil if the session is missing or still in progress
+func (session *Session) fetchTraceSession(id UUID) (*Trace, error) {
+	rows, err := session.execTraceQuery(
+		"SELECT coordinator, duration, parameters, request, started_at FROM system_traces.sessions WHERE session_id = ?",
+		id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, nil
+	}
+	trace := &Trace{ID: id, Duration: -1}
+	if err := rows.Scan(&trace.Coordinator, &trace.Duration,
+		&trace.Parameters, &trace.Request, &trace.StartedAt); err != nil {
+		return nil, err
+	}
+	// the duration is written only once the request completed
+	if trace.Duration < 0 {
+		return nil, nil
+	}
+	return trace, nil
+}
+
+func (session *Session) fetchTraceEvents(trace *Trace) error {
+	rows, err := session.execTraceQuery(
+		"SELECT event_id, activity, source, source_elapsed, thread FROM system_traces.events WHERE session_id = ?",
+		trace.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event TraceEvent
+		if err := rows.Scan(&event.ID, &event.Activity, &event.Source,
+			&event.SourceElapsed, &event.Thread); err != nil {
+			return err
+		}
+		trace.Events = append(trace.Events, event)
+	}
+	return nil
+}
+
+func (session *Session) execTraceQuery(query string, id UUID) (*Rows, error) {
+	stmt, err := session.Query(query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	return stmt.WithConsistency(ONE).Exec()
+}
@@ -0,0 +1,62 @@
+package cassandra_test
+
+import (
+	"golang-driver/cassandra/test"
+	"testing"
+)
+
+func TestTracing(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	stmt, err := session.Query("select keyspace_name from system.schema_keyspaces")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.WithTracing(true).Exec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	id, ok := rows.TracingID()
+	if !ok {
+		t.Fatal("traced statement should return a tracing id")
+	}
+	trace, err := session.FetchTrace(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trace.ID != id {
+		t.Errorf("trace id %s != %s", trace.ID, id)
+	}
+	if trace.Coordinator == nil {
+		t.Error("trace should have a coordinator")
+	}
+	if trace.Duration <= 0 {
+		t.Errorf("trace duration should be positive: %d", trace.Duration)
+	}
+	if len(trace.Events) == 0 {
+		t.Error("trace should have events")
+	}
+	if len(trace.Sources()) == 0 {
+		t.Error("trace should have at least one source host")
+	}
+}
+
+func TestNoTracing(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	rows, err := session.Exec("select keyspace_name from system.schema_keyspaces")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	if id, ok := rows.TracingID(); ok {
+		t.Errorf("untraced statement returned tracing id %s", id)
+	}
+}
@@ -53,7 +53,7 @@ func TestTuple(t *testing.T) {
 	defer test.TearDown(tupleCleanup)
 
 	testSelectTuple(t, session, 1,
-		[]interface{}{"(true, 1, abc)", "tuple<true boolean, 1 int, abc varchar>", 1, "abc"})
+		[]interface{}{"(true, 1, 'abc')", "tuple<true boolean, 1 int, abc varchar>", 1, "abc"})
 	testInsertTupleUsingStatement(t, session)
 	testInsertTupleUsingPreparedStatement(t, session)
 
@@ -125,7 +125,7 @@ func testInsertTupleUsingStatement(t *testing.T, s *cassandra.Session) {
 	}
 
 	testSelectTuple(t, s, 101,
-		[]interface{}{"(false, 101, statement)",
+		[]interface{}{"(false, 101, 'statement')",
 			"tuple<false boolean, 101 int, statement varchar>",
 			101,
 			"statement"})
@@ -6,16 +6,37 @@ package cassandra
 // #include <cassandra.h>
 import "C"
 import (
+	"database/sql"
+	"encoding"
 	"errors"
 	"fmt"
 	"math/big"
 	"net"
 	"reflect"
+	"time"
 	"unsafe"
 )
 
 func read(value *C.CassValue, cassType CassType, dst interface{}) (bool, error) {
 	// fmt.Printf("CVT1: 0x%04X\n", cassType)
+	if codec, ok := codecForDst(dst); ok {
+		return readWithCodec(value, cassType, codec, dst)
+	}
+	if n, ok := dst.(nullableDst); ok {
+		if isNull(value) {
+			n.scanTarget(false)
+			return false, nil
+		}
+		return read(value, cassType, n.scanTarget(true))
+	}
+	if dst != nil && !isBuiltinType(reflect.TypeOf(dst)) {
+		switch dst := dst.(type) {
+		case sql.Scanner:
+			return readIntoScanner(value, cassType, dst)
+		case encoding.TextUnmarshaler:
+			return readIntoTextUnmarshaler(value, cassType, dst)
+		}
+	}
 	if isNull(value) && canBeNil(dst) {
 		dstVal := reflect.ValueOf(dst)
 		nilVal := reflect.Zero(dstVal.Type().Elem())
@@ -51,6 +72,8 @@ func read(value *C.CassValue, cassType CassType, dst interface{}) (bool, error)
 		return readUUID(value, cassType, dst)
 	case CASS_VALUE_TYPE_INET:
 		return readInet(value, cassType, dst)
+	case CASS_VALUE_TYPE_DURATION:
+		return readDuration(value, cassType, dst)
 	case CASS_VALUE_TYPE_LIST:
 		return readList(value, cassType, dst)
 	case CASS_VALUE_TYPE_SET:
@@ -66,6 +89,66 @@ func read(value *C.CassValue, cassType CassType, dst interface{}) (bool, error)
 		cassType.String(), dst)
 }
 
+// reads the native value of the Cassandra type and lets the codec
+// convert it
+func readWithCodec(value *C.CassValue, cassType CassType, codec Codec, dst interface{}) (bool, error) {
+	if isNull(value) {
+		return false, codec.Decode(nil, dst)
+	}
+	found, native, err := readNative(value, cassType)
+	if err != nil {
+		return found, err
+	}
+	return found, codec.Decode(native, dst)
+}
+
+// passes the value to a sql.Scanner as one of the types used by
+// database/sql drivers
+func readIntoScanner(value *C.CassValue, cassType CassType, dst sql.Scanner) (bool, error) {
+	if isNull(value) {
+		return false, dst.Scan(nil)
+	}
+	found, native, err := readNative(value, cassType)
+	if err != nil {
+		return found, err
+	}
+	return found, dst.Scan(DriverValue(native))
+}
+
+// passes the text representation of the value to an
+// encoding.TextUnmarshaler; null values are left untouched
+func readIntoTextUnmarshaler(value *C.CassValue, cassType CassType, dst encoding.TextUnmarshaler) (bool, error) {
+	switch cassType.primary {
+	case CASS_VALUE_TYPE_LIST, CASS_VALUE_TYPE_SET, CASS_VALUE_TYPE_MAP,
+		CASS_VALUE_TYPE_TUPLE, CASS_VALUE_TYPE_UDT:
+		return true, fmt.Errorf("cannot read %s type into %T", cassType.String(), dst)
+	}
+	if isNull(value) {
+		return false, nil
+	}
+	found, native, err := readNative(value, cassType)
+	if err != nil {
+		return found, err
+	}
+	text, err := nativeText(native)
+	if err != nil {
+		return found, err
+	}
+	return found, dst.UnmarshalText(text)
+}
+
+// reads a value into the Go type it's read into when scanning an
+// interface{}
+func readNative(value *C.CassValue, cassType CassType) (bool, interface{}, error) {
+	nativeType, err := goTypeFor(cassType)
+	if err != nil {
+		return true, nil, err
+	}
+	native := reflect.New(nativeType)
+	found, err := read(value, cassType, native.Interface())
+	return found, native.Elem().Interface(), err
+}
+
 func readBlob(value *C.CassValue, cassType CassType, dst interface{}) (bool, error) {
 	switch dst := dst.(type) {
 	case *[]byte:
@@ -294,7 +377,7 @@ func readInt(value *C.CassValue, cassType CassType, dst interface{}) (bool, erro
 				val = reflect.ValueOf(int16(v))
 			case CASS_VALUE_TYPE_INT:
 				val = reflect.ValueOf(int(v))
-			case CASS_VALUE_TYPE_BIGINT:
+			case CASS_VALUE_TYPE_BIGINT, CASS_VALUE_TYPE_COUNTER:
 				val = reflect.ValueOf(v)
 			}
 			dstVal.Set(val)
@@ -351,7 +434,8 @@ func valAsInt(value *C.CassValue, cassType CassType) (found bool, v int64, err e
 		}
 		return
 
-	case CASS_VALUE_TYPE_BIGINT, CASS_VALUE_TYPE_TIME, CASS_VALUE_TYPE_TIMESTAMP:
+	case CASS_VALUE_TYPE_BIGINT, CASS_VALUE_TYPE_COUNTER,
+		CASS_VALUE_TYPE_TIME, CASS_VALUE_TYPE_TIMESTAMP:
 		var ival C.cass_int64_t
 		retc := C.cass_value_get_int64(value, &ival)
 		switch retc {
@@ -611,19 +695,21 @@ func valAsUUID(value *C.CassValue) (found bool, u UUID, err error) {
 	switch retc {
 	case C.CASS_OK:
 		found = true
-		buf := (*C.char)(C.malloc(C.CASS_UUID_STRING_LENGTH))
-		defer C.free(unsafe.Pointer(buf))
-
-		C.cass_uuid_string(cuuid, buf)
-		suuid := C.GoString(buf)
-
-		u, err = ParseUUID(suuid)
+		u, err = uuidFromC(cuuid)
 		return
 	default:
 		return true, u, errors.New(C.GoString(C.cass_error_desc(retc)))
 	}
 }
 
+func uuidFromC(cuuid C.struct_CassUuid_) (UUID, error) {
+	buf := (*C.char)(C.malloc(C.CASS_UUID_STRING_LENGTH))
+	defer C.free(unsafe.Pointer(buf))
+
+	C.cass_uuid_string(cuuid, buf)
+	return ParseUUID(C.GoString(buf))
+}
+
 func readTime(value *C.CassValue, cassType CassType, dst interface{}) (bool, error) {
 	switch dst := dst.(type) {
 	case *Time:
@@ -682,6 +768,13 @@ func readDate(value *C.CassValue, cassType CassType, dst interface{}) (bool, err
 		f, v, err := valAsInt(value, cassType)
 		dst.days = uint32(v)
 		return f, err
+	case *time.Time:
+		if isNull(value) {
+			return false, nil
+		}
+		f, v, err := valAsInt(value, cassType)
+		*dst = Date{uint32(v)}.Time()
+		return f, err
 	case *uint32:
 		if isNull(value) {
 			return false, nil
@@ -731,6 +824,13 @@ func readTimestamp(value *C.CassValue, cassType CassType, dst interface{}) (bool
 		f, v, err := valAsInt(value, cassType)
 		dst.secondsSinceEpoch = v
 		return f, err
+	case *time.Time:
+		if isNull(value) {
+			return false, nil
+		}
+		f, v, err := valAsInt(value, cassType)
+		*dst = NewTimestamp(v).Time()
+		return f, err
 	case *int64:
 		if isNull(value) {
 			return false, nil
@@ -771,6 +871,57 @@ func readTimestamp(value *C.CassValue, cassType CassType, dst interface{}) (bool
 		dst)
 }
 
+func readDuration(value *C.CassValue, cassType CassType, dst interface{}) (bool, error) {
+	switch dst := dst.(type) {
+	case *Duration:
+		if isNull(value) {
+			return false, nil
+		}
+		f, d, err := valAsDuration(value)
+		*dst = d
+		return f, err
+	case *time.Duration:
+		if isNull(value) {
+			return false, nil
+		}
+		f, d, err := valAsDuration(value)
+		if err != nil {
+			return f, err
+		}
+		v, exact := d.Duration()
+		if !exact {
+			return f, fmt.Errorf("duration %s cannot be read into time.Duration (it has months or days)",
+				d.String())
+		}
+		*dst = v
+		return f, nil
+	case *interface{}:
+		if isNull(value) {
+			return false, nil
+		}
+		f, d, err := valAsDuration(value)
+		*dst = d
+		return f, err
+	}
+
+	return true, fmt.Errorf("cannot read %s type into %T", cassType.String(),
+		dst)
+}
+
+func valAsDuration(value *C.CassValue) (found bool, d Duration, err error) {
+	var months, days C.cass_int32_t
+	var nanos C.cass_int64_t
+	retc := C.cass_value_get_duration(value, &months, &days, &nanos)
+	switch retc {
+	case C.CASS_OK:
+		return true, Duration{int32(months), int32(days), int64(nanos)}, nil
+	case C.CASS_ERROR_LIB_NULL_VALUE:
+		return false, Duration{}, nil
+	default:
+		return true, Duration{}, newError(retc)
+	}
+}
+
 func readInet(value *C.CassValue, cassType CassType, dst interface{}) (bool, error) {
 	switch dst := dst.(type) {
 	case *net.IP:
@@ -820,6 +971,8 @@ func readList(value *C.CassValue, cassType CassType, dst interface{}) (bool, err
 	}
 	dstVal = dstVal.Elem()
 	switch dstVal.Type().Kind() {
+	case reflect.Interface:
+		return readIntoInterface(value, cassType, dstVal)
 	case reflect.Slice:
 		if isNull(value) {
 			dstVal.Set(reflect.Zero(dstVal.Type()))
@@ -867,6 +1020,9 @@ func readMap(value *C.CassValue, cassType CassType, dst interface{}) (bool, erro
 			cassType.String(), dst)
 	}
 	dstVal = dstVal.Elem()
+	if dstVal.Type().Kind() == reflect.Interface {
+		return readIntoInterface(value, cassType, dstVal)
+	}
 	if dstVal.Type().Kind() != reflect.Map {
 		return true, fmt.Errorf("cannot read %s into non-pointer %T",
 			cassType.String(), dst)
@@ -914,6 +1070,9 @@ func readSet(value *C.CassValue, cassType CassType, dst interface{}) (bool, erro
 			cassType.String(), dst)
 	}
 	dstVal = dstVal.Elem()
+	if dstVal.Type().Kind() == reflect.Interface {
+		return readIntoInterface(value, cassType, dstVal)
+	}
 	if dstVal.Type().Kind() != reflect.Map {
 		return true, fmt.Errorf("cannot read %s into non-pointer %T",
 			cassType.String(), dst)
@@ -924,8 +1083,12 @@ func readSet(value *C.CassValue, cassType CassType, dst interface{}) (bool, erro
 	}
 	t := dstVal.Type()
 	dstVal.Set(reflect.MakeMap(t))
-	inSet := true
-	trueVal := reflect.ValueOf(&inSet)
+	// the value of the elements: true for a map[T]bool, the zero
+	// value otherwise (e.g. for a SetOf)
+	inSet := reflect.New(t.Elem()).Elem()
+	if inSet.Kind() == reflect.Bool {
+		inSet.SetBool(true)
+	}
 
 	colIter := C.cass_iterator_from_collection(value)
 	defer C.cass_iterator_free(colIter)
@@ -941,7 +1104,7 @@ func readSet(value *C.CassValue, cassType CassType, dst interface{}) (bool, erro
 			return true, err
 		}
 
-		dstVal.SetMapIndex(key.Elem(), trueVal.Elem())
+		dstVal.SetMapIndex(key.Elem(), inSet)
 		b = C.cass_iterator_next(colIter)
 	}
 
@@ -975,12 +1138,110 @@ func readTuple(value *C.CassValue, cassType CassType, dst interface{}) (bool, er
 		dst.kind = CTuple.Specialize(subtypes...)
 
 		return true, nil
+	case *interface{}:
+		if isNull(value) {
+			return false, nil
+		}
+		tuple := new(Tuple)
+		f, err := readTuple(value, cassType, tuple)
+		*dst = tuple
+		return f, err
 	}
 
 	return true, fmt.Errorf("cannot read %s type into %T", cassType.String(),
 		dst)
 }
 
+// Reads a collection into an interface{} using the Go type
+// matching the collection type (see goTypeFor).
+func readIntoInterface(value *C.CassValue, cassType CassType, dstVal reflect.Value) (bool, error) {
+	if isNull(value) {
+		dstVal.Set(reflect.Zero(dstVal.Type()))
+		return false, nil
+	}
+	t, err := goTypeFor(cassType)
+	if err != nil {
+		return true, err
+	}
+	v := reflect.New(t)
+	f, err := read(value, cassType, v.Interface())
+	if err != nil {
+		return f, err
+	}
+	dstVal.Set(v.Elem())
+	return f, nil
+}
+
+// Returns the Go type values of the given Cassandra type are read into
+// when the destination is an interface{}. Sets are read into maps to
+// bool, so the type of their elements must be comparable.
+func goTypeFor(cassType CassType) (reflect.Type, error) {
+	switch cassType.primary {
+	case CASS_VALUE_TYPE_ASCII, CASS_VALUE_TYPE_TEXT, CASS_VALUE_TYPE_VARCHAR:
+		return reflect.TypeOf(""), nil
+	case CASS_VALUE_TYPE_BOOLEAN:
+		return reflect.TypeOf(false), nil
+	case CASS_VALUE_TYPE_TINY_INT:
+		return reflect.TypeOf(int8(0)), nil
+	case CASS_VALUE_TYPE_SMALL_INT:
+		return reflect.TypeOf(int16(0)), nil
+	case CASS_VALUE_TYPE_INT:
+		return reflect.TypeOf(int(0)), nil
+	case CASS_VALUE_TYPE_BIGINT, CASS_VALUE_TYPE_COUNTER:
+		return reflect.TypeOf(int64(0)), nil
+	case CASS_VALUE_TYPE_VARINT:
+		return reflect.TypeOf(new(big.Int)), nil
+	case CASS_VALUE_TYPE_FLOAT:
+		return reflect.TypeOf(float32(0)), nil
+	case CASS_VALUE_TYPE_DOUBLE:
+		return reflect.TypeOf(float64(0)), nil
+	case CASS_VALUE_TYPE_DECIMAL:
+		return reflect.TypeOf(new(Decimal)), nil
+	case CASS_VALUE_TYPE_TIMESTAMP:
+		return reflect.TypeOf(Timestamp{}), nil
+	case CASS_VALUE_TYPE_DATE:
+		return reflect.TypeOf(Date{}), nil
+	case CASS_VALUE_TYPE_TIME:
+		return reflect.TypeOf(Time(0)), nil
+	case CASS_VALUE_TYPE_UUID, CASS_VALUE_TYPE_TIMEUUID:
+		return reflect.TypeOf(UUID{}), nil
+	case CASS_VALUE_TYPE_INET:
+		return reflect.TypeOf(net.IP{}), nil
+	case CASS_VALUE_TYPE_BLOB:
+		return reflect.TypeOf([]byte{}), nil
+	case CASS_VALUE_TYPE_DURATION:
+		return reflect.TypeOf(Duration{}), nil
+	case CASS_VALUE_TYPE_TUPLE:
+		return reflect.TypeOf(new(Tuple)), nil
+	case CASS_VALUE_TYPE_LIST, CASS_VALUE_TYPE_SET, CASS_VALUE_TYPE_MAP:
+		subtypes := make([]reflect.Type, len(cassType.subtypes))
+		for i, st := range cassType.subtypes {
+			t, err := goTypeFor(st)
+			if err != nil {
+				return nil, err
+			}
+			subtypes[i] = t
+		}
+		switch {
+		case cassType.primary == CASS_VALUE_TYPE_LIST && len(subtypes) == 1:
+			return reflect.SliceOf(subtypes[0]), nil
+		case cassType.primary == CASS_VALUE_TYPE_SET && len(subtypes) == 1:
+			if !subtypes[0].Comparable() {
+				return nil, fmt.Errorf("cannot read %s into a Go map (%s is not comparable)",
+					cassType.String(), subtypes[0].String())
+			}
+			return reflect.MapOf(subtypes[0], reflect.TypeOf(false)), nil
+		case cassType.primary == CASS_VALUE_TYPE_MAP && len(subtypes) == 2:
+			if !subtypes[0].Comparable() {
+				return nil, fmt.Errorf("cannot read %s into a Go map (%s is not comparable)",
+					cassType.String(), subtypes[0].String())
+			}
+			return reflect.MapOf(subtypes[0], subtypes[1]), nil
+		}
+	}
+	return reflect.TypeOf((*interface{})(nil)).Elem(), nil
+}
+
 func readUDT(value *C.CassValue, cassType CassType, dst interface{}) (bool, error) {
 	return true, fmt.Errorf("cannot read %s type into %T", cassType.String(),
 		dst)
@@ -21,6 +21,13 @@ import (
 type CassType struct {
 	primary  int
 	subtypes []CassType
+	frozen   bool
+	// UDTs only: the subtypes are the types of the fields
+	keyspace   string
+	name       string
+	fieldNames []string
+	// custom types only: the Java class implementing the type
+	class string
 }
 
 // Predefined CassTypes for all known Cassandra data types.
@@ -28,6 +35,7 @@ var (
 	CUnknown   = newCassType(CASS_VALUE_TYPE_UNKNOWN)
 	CAscii     = newCassType(CASS_VALUE_TYPE_ASCII)
 	CBigInt    = newCassType(CASS_VALUE_TYPE_BIGINT)
+	CCounter   = newCassType(CASS_VALUE_TYPE_COUNTER)
 	CBlob      = newCassType(CASS_VALUE_TYPE_BLOB)
 	CBoolean   = newCassType(CASS_VALUE_TYPE_BOOLEAN)
 	CDecimal   = newCassType(CASS_VALUE_TYPE_DECIMAL)
@@ -45,21 +53,41 @@ var (
 	CTime      = newCassType(CASS_VALUE_TYPE_TIME)
 	CSmallInt  = newCassType(CASS_VALUE_TYPE_SMALL_INT)
 	CTinyInt   = newCassType(CASS_VALUE_TYPE_TINY_INT)
+	CDuration  = newCassType(CASS_VALUE_TYPE_DURATION)
 	// collections
 	CList  = newCassType(CASS_VALUE_TYPE_LIST)
 	CSet   = newCassType(CASS_VALUE_TYPE_SET)
 	CMap   = newCassType(CASS_VALUE_TYPE_MAP)
 	CTuple = newCassType(CASS_VALUE_TYPE_TUPLE)
 	CUdt   = newCassType(CASS_VALUE_TYPE_UDT)
+	// custom types implemented by a Java class on the server
+	CCustom = newCassType(CASS_VALUE_TYPE_CUSTOM)
 )
 
 // Specialize a collection type (list, set, map, tuple) with the
 // type(s) of its elements
 func (ct CassType) Specialize(subTypes ...CassType) CassType {
-	return CassType{primary: ct.primary, subtypes: subTypes}
+	ct.subtypes = subTypes
+	return ct
+}
+
+// Returns the frozen version of a collection, tuple or UDT type,
+// as needed for nesting collections (e.g. list<frozen<set<int>>>)
+func (ct CassType) Frozen() CassType {
+	ct.frozen = true
+	return ct
+}
+
+func (ct CassType) IsFrozen() bool {
+	return ct.frozen
 }
 
 func (ct CassType) String() string {
+	if ct.frozen {
+		unfrozen := ct
+		unfrozen.frozen = false
+		return fmt.Sprintf("frozen<%s>", unfrozen.String())
+	}
 	switch ct.primary {
 	case CASS_VALUE_TYPE_LIST:
 		if len(ct.subtypes) > 0 {
@@ -123,9 +151,14 @@ func (ct CassType) String() string {
 		return "timeuuid"
 	case CASS_VALUE_TYPE_INET:
 		return "inet"
+	case CASS_VALUE_TYPE_DURATION:
+		return "duration"
 	case CASS_VALUE_TYPE_UDT:
-		return "udt"
+		return ct.udtString()
 	case CASS_VALUE_TYPE_CUSTOM:
+		if ct.class != "" {
+			return "'" + strings.Replace(ct.class, "'", "''", -1) + "'"
+		}
 		return "custom"
 	default:
 		return "UNKNOWN"
@@ -156,9 +189,11 @@ func (t Timestamp) String() string {
 	return t.Time().String()
 }
 
+// Returns a representation that can be used directly in CQL. This is
+// the raw value, which is the value bound to statements: Cassandra
+// reads it as milliseconds since Epoch.
 func (t Timestamp) NativeString() string {
-	// FIXME: should return the value as represented in CQL
-	return fmt.Sprintf("%d", t.secondsSinceEpoch)
+	return strconv.FormatInt(t.secondsSinceEpoch, 10)
 }
 
 func (t Timestamp) Raw() int64 {
@@ -166,26 +201,89 @@ func (t Timestamp) Raw() int64 {
 }
 
 // Cassandra Date is a 32-bit unsigned integer representing
-// the number of days with Epoch (1970-1-1) at the center of the range
+// the number of days with Epoch (1970-1-1) at the center of the range.
+// Dates use the proleptic Gregorian calendar, with astronomical year
+// numbering (the year 0 is 1 BC), and span about 5.8 million years
+// around Epoch.
 type Date struct {
 	days uint32
 }
 
-// Create a new Date.
-func NewDate(year int, month time.Month, day int) Date {
-	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
-	d := date.Sub(Epoch)
-	var days int64 = int64(d.Hours()) / 24
-	var udays = uint32(days + math.MaxInt32 + 1)
+const (
+	minDateDays = math.MinInt32
+	maxDateDays = math.MaxInt32
+)
 
-	return Date{udays}
+// Create a new Date. The month and day may be outside of their usual
+// ranges and are normalized like with time.Date (e.g. October 32
+// converts to November 1). Returns an error if the date is out of the
+// range of the `date` type.
+func NewDate(year int, month time.Month, day int) (Date, error) {
+	return newDate(int64(year), int64(month), int64(day))
 }
 
-var datePatterns = []string{
-	"2006-01-02",
-	"2006-01-2",
-	"2006-1-02",
-	"2006-1-2",
+// Returns the Date of t in the location of t, or an error if it's out
+// of the range of the `date` type.
+func DateOf(t time.Time) (Date, error) {
+	year, month, day := t.Date()
+	return NewDate(year, month, day)
+}
+
+func newDate(year, month, day int64) (Date, error) {
+	// normalizes the month to [1, 12]
+	month--
+	year += floorDiv(month, 12)
+	month = month - floorDiv(month, 12)*12 + 1
+	if year < -6000000 || year > 6000000 {
+		return Date{}, fmt.Errorf("year %d out of the range of the date type", year)
+	}
+	return dateFromDays(daysFromCivil(year, month, 1) + day - 1)
+}
+
+func dateFromDays(days int64) (Date, error) {
+	if days < minDateDays || days > maxDateDays {
+		return Date{}, fmt.Errorf("%d days since Epoch is out of the range of the date type", days)
+	}
+	return Date{uint32(days - minDateDays)}, nil
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// returns the number of days since Epoch of a date of the proleptic
+// Gregorian calendar (see http://howardhinnant.github.io/date_algorithms.html)
+func daysFromCivil(year, month, day int64) int64 {
+	if month <= 2 {
+		year--
+	}
+	era := floorDiv(year, 400)
+	yoe := year - era*400
+	mp := (month + 9) % 12
+	doy := (153*mp+2)/5 + day - 1
+	doe := yoe*365 + yoe/4 - yoe/100 + doy
+	return era*146097 + doe - 719468
+}
+
+// inverse of daysFromCivil
+func civilFromDays(days int64) (int64, time.Month, int64) {
+	days += 719468
+	era := floorDiv(days, 146097)
+	doe := days - era*146097
+	yoe := (doe - doe/1460 + doe/36524 - doe/146096) / 365
+	doy := doe - (365*yoe + yoe/4 - yoe/100)
+	mp := (5*doy + 2) / 153
+	day := doy - (153*mp+2)/5 + 1
+	month := (mp+2)%12 + 1
+	year := yoe + era*400
+	if month <= 2 {
+		year++
+	}
+	return year, time.Month(month), day
 }
 
 // Creates a new Date value from the string representation.
@@ -194,119 +292,294 @@ var datePatterns = []string{
 // * yyyy-m-d
 // * yyyy-mm-d
 // * yyyy-m-dd
+// The year can have more than 4 digits and be preceded by a sign
+// (e.g. -0044-03-15 or +10000-01-01).
 // If the value cannot be parsed to a valid date, this function
 // return a non-nil error
 func ParseDate(s string) (Date, error) {
-	var t time.Time
-	var err error
-	for _, p := range datePatterns {
-		t, err = time.Parse(p, s)
-		if err == nil {
-			break
+	invalid := fmt.Errorf("invalid date %q (expected yyyy-mm-dd)", s)
+	str, negative := s, false
+	if str != "" && (str[0] == '-' || str[0] == '+') {
+		str, negative = str[1:], str[0] == '-'
+	}
+	parts := strings.Split(str, "-")
+	if len(parts) != 3 || len(parts[0]) > 7 || len(parts[1]) > 2 || len(parts[2]) > 2 {
+		return Date{}, invalid
+	}
+	var ymd [3]int64
+	for i, part := range parts {
+		if part == "" || leadingDigits(part) != len(part) {
+			return Date{}, invalid
 		}
+		ymd[i], _ = strconv.ParseInt(part, 10, 64)
 	}
+	if negative {
+		ymd[0] = -ymd[0]
+	}
+	year, month, day := ymd[0], ymd[1], ymd[2]
+	if month < 1 || month > 12 || day < 1 || day > daysIn(year, time.Month(month)) {
+		return Date{}, invalid
+	}
+	date, err := newDate(year, month, day)
 	if err != nil {
-		return Date{}, err
+		return Date{}, invalid
+	}
+	return date, nil
+}
+
+func daysIn(year int64, month time.Month) int64 {
+	if month == time.December {
+		return 31
 	}
-	return NewDate(t.Year(), t.Month(), t.Day()), nil
+	return daysFromCivil(year, int64(month)+1, 1) - daysFromCivil(year, int64(month), 1)
+}
+
+// returns the number of days since Epoch
+func (d Date) daysSinceEpoch() int64 {
+	return int64(d.days) + minDateDays
+}
+
+// Returns the year, month and day of d.
+func (d Date) Date() (year int, month time.Month, day int) {
+	y, m, dd := civilFromDays(d.daysSinceEpoch())
+	return int(y), m, int(dd)
+}
+
+func (d Date) Year() int {
+	year, _, _ := d.Date()
+	return year
+}
+
+func (d Date) Month() time.Month {
+	_, month, _ := d.Date()
+	return month
+}
+
+func (d Date) Day() int {
+	_, _, day := d.Date()
+	return day
+}
+
+func (d Date) Weekday() time.Weekday {
+	// Epoch was a Thursday
+	days := d.daysSinceEpoch() + int64(time.Thursday)
+	return time.Weekday(days - floorDiv(days, 7)*7)
+}
+
+// Returns the date the given number of days (possibly negative) after
+// d. Returns an error if the result is out of the range of the `date`
+// type.
+func (d Date) AddDays(days int) (Date, error) {
+	return dateFromDays(d.daysSinceEpoch() + int64(days))
+}
+
+// Returns the number of days from other to d, which is negative if
+// other is later.
+func (d Date) Sub(other Date) int64 {
+	return int64(d.days) - int64(other.days)
+}
+
+func (d Date) Before(other Date) bool {
+	return d.days < other.days
+}
+
+func (d Date) After(other Date) bool {
+	return d.days > other.days
 }
 
 // Only the year, month, day part are set in the returned time.Time
+// (in UTC).
 func (d Date) Time() time.Time {
-	var v int64 = int64(d.days) - math.MaxInt32 - 1
-	return Epoch.Add(time.Duration(v*24) * time.Hour)
+	return d.In(time.UTC)
+}
+
+// Returns the midnight starting the day of d in the given location.
+func (d Date) In(loc *time.Location) time.Time {
+	year, month, day := d.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
 }
 
 func (d Date) Raw() uint32 {
 	return d.days
 }
 
+// Returns the date as yyyy-mm-dd. Years before 0 are preceded by a
+// minus sign and years after 9999 by a plus sign (as Cassandra
+// formats them).
 func (d Date) String() string {
-	return d.Time().Format("2006-01-02")
+	year, month, day := d.Date()
+	var sign string
+	switch {
+	case year < 0:
+		sign, year = "-", -year
+	case year > 9999:
+		sign = "+"
+	}
+	return fmt.Sprintf("%s%04d-%02d-%02d", sign, year, int(month), day)
 }
 
-// Returns a representation that can be used directly in CQL
+// Returns a representation that can be used directly in CQL, e.g.
+// '2016-03-01'. The dates whose years don't have 4 digits are given
+// as their raw number of days, e.g. '2147483648' for 1970-01-01.
 func (d Date) NativeString() string {
-	return fmt.Sprintf("'%d'", d.days)
+	if year := d.Year(); year < 0 || year > 9999 {
+		return fmt.Sprintf("'%d'", d.days)
+	}
+	return "'" + d.String() + "'"
 }
 
 // Cassandra `time` type represents a time of day
-// with no date (and no notion of time zone)
+// with no date (and no notion of time zone) as the number of
+// nanoseconds since midnight
 type Time int64
 
 var nanosInADay int64 = 24 * int64(time.Hour)
 
+// Creates a new Time. Returns an error if a component is out of
+// range (e.g. 60 minutes).
 func NewTime(hours, minutes, seconds, nanos uint) (Time, error) {
+	if hours > 23 || minutes > 59 || seconds > 59 || nanos > 999999999 {
+		return 0, fmt.Errorf("invalid time %d:%d:%d.%d (components out of range)",
+			hours, minutes, seconds, nanos)
+	}
 	var nanotime int64 = int64(hours)*int64(time.Hour) +
 		int64(minutes)*int64(time.Minute) +
 		int64(seconds)*int64(time.Second) +
 		int64(nanos)
-	if nanotime < 0 || nanotime > nanosInADay {
-		return 0, fmt.Errorf("Time value must be bigger than 0 and less than the number of nanoseconds in a day (%d)", nanosInADay)
-	}
 	return Time(nanotime), nil
 }
 
+// Returns the Time at the given duration after midnight. Returns an
+// error if the duration is negative or not less than 24 hours.
+func TimeFromDuration(d time.Duration) (Time, error) {
+	t := Time(d)
+	if err := t.validate(); err != nil {
+		return 0, err
+	}
+	return t, nil
+}
+
+// Returns the time of day of t, in the location of t.
+func TimeOf(t time.Time) Time {
+	h, m, s := t.Clock()
+	return Time(int64(h)*int64(time.Hour) + int64(m)*int64(time.Minute) +
+		int64(s)*int64(time.Second) + int64(t.Nanosecond()))
+}
+
+func (t Time) validate() error {
+	if int64(t) < 0 || int64(t) >= nanosInADay {
+		return fmt.Errorf("time must be between 0 and %d nanoseconds (got %d)", nanosInADay-1, int64(t))
+	}
+	return nil
+}
+
+// Parses a time in the format accepted by Cassandra: hh:mm:ss
+// optionally followed by a fraction of a second of up to 9 digits
+// (e.g. 08:30:00, 08:30:00.5, 08:30:00.123456789).
 func ParseTime(str string) (Time, error) {
-	parts := strings.Split(str, ".")
-	hms := strings.Split(parts[0], ":")
+	invalid := fmt.Errorf("invalid time %q (expected hh:mm:ss[.fffffffff])", str)
+	clock, fraction := str, ""
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		clock, fraction = str[:i], str[i+1:]
+		if fraction == "" || len(fraction) > 9 || leadingDigits(fraction) != len(fraction) {
+			return 0, invalid
+		}
+	}
+	hms := strings.Split(clock, ":")
 	if len(hms) != 3 {
-		return 0, fmt.Errorf("Time must be in format hh:mm:ss.nnnnnnnnn")
+		return 0, invalid
 	}
-	var nanotime int64
-	n, err := strconv.ParseInt(hms[0], 10, 64)
-	if err != nil {
-		return 0, fmt.Errorf("Time must be in format hh:mm:ss.nnnnnnnnn")
+	var components [3]uint
+	for i, part := range hms {
+		if part == "" || len(part) > 2 || leadingDigits(part) != len(part) {
+			return 0, invalid
+		}
+		n, _ := strconv.ParseUint(part, 10, 8)
+		components[i] = uint(n)
 	}
-	nanotime += n * int64(time.Hour)
-	n, err = strconv.ParseInt(hms[1], 10, 64)
-	if err != nil {
-		return 0, fmt.Errorf("Time must be in format hh:mm:ss.nnnnnnnnn")
+	var nanos uint64
+	if fraction != "" {
+		nanos, _ = strconv.ParseUint(fraction+strings.Repeat("0", 9-len(fraction)), 10, 32)
 	}
-	nanotime += n * int64(time.Minute)
-	n, err = strconv.ParseInt(hms[2], 10, 64)
+	t, err := NewTime(components[0], components[1], components[2], uint(nanos))
 	if err != nil {
-		return 0, fmt.Errorf("Time must be in format hh:mm:ss.nnnnnnnnn")
-	}
-	nanotime += n * int64(time.Second)
-	if len(parts) > 1 {
-		padded := parts[1] + strings.Repeat("0", (9-len(parts[1])))
-		fmt.Printf(padded)
-		n, err = strconv.ParseInt(padded, 10, 64)
-		if err != nil {
-			return 0, fmt.Errorf("Time must be in format hh:mm:ss.nnnnnnnnn")
-		}
-		nanotime += n
+		return 0, invalid
 	}
-	return Time(nanotime), nil
+	return t, nil
 }
 
 func (t Time) Hours() uint {
-	return uint(math.Floor(float64(t) / float64(time.Hour)))
-
+	return uint(int64(t) / int64(time.Hour))
 }
 
 func (t Time) Minutes() uint {
-	minutes := math.Floor(float64(t) / float64(time.Minute))
-	return uint(math.Remainder(minutes, 60))
+	return uint(int64(t) / int64(time.Minute) % 60)
 }
 
 func (t Time) Seconds() uint {
-	seconds := math.Floor(float64(t) / float64(time.Second))
-	return uint(math.Remainder(seconds, 60))
+	return uint(int64(t) / int64(time.Second) % 60)
 }
 
 func (t Time) Nanoseconds() uint {
-	return uint(math.Remainder(float64(t), float64(time.Second)))
+	return uint(int64(t) % int64(time.Second))
 }
 
 func (t Time) Raw() int64 {
 	return int64(t)
 }
 
+// Returns the time elapsed since midnight.
+func (t Time) Duration() time.Duration {
+	return time.Duration(t)
+}
+
+// Returns the time.Time of this time of day on the date (and in the
+// location) of the given time.Time.
+func (t Time) On(date time.Time) time.Time {
+	y, m, d := date.Date()
+	return time.Date(y, m, d, int(t.Hours()), int(t.Minutes()), int(t.Seconds()),
+		int(t.Nanoseconds()), date.Location())
+}
+
+// Returns the time t+d. Returns an error if the result is not within
+// the same day (i.e. before midnight or at or after the next midnight).
+func (t Time) Add(d time.Duration) (Time, error) {
+	if (d > 0 && int64(d) >= nanosInADay-int64(t)) || (d < 0 && int64(d) < -int64(t)) {
+		return 0, fmt.Errorf("%s %+d ns is outside of the day", t.String(), int64(d))
+	}
+	return t + Time(d), nil
+}
+
+// Returns the duration t-other, which is negative if other is later.
+func (t Time) Sub(other Time) time.Duration {
+	return time.Duration(t - other)
+}
+
+func (t Time) Before(other Time) bool {
+	return t < other
+}
+
+func (t Time) After(other Time) bool {
+	return t > other
+}
+
+// Returns the time as hh:mm:ss.fffffffff (as Cassandra formats it).
 func (t Time) String() string {
-	return fmt.Sprintf("%02d:%02d:%02d.%d", t.Hours(), t.Minutes(), t.Seconds(),
-		t.Nanoseconds())
+	return t.Format(9)
+}
+
+// Returns the time as hh:mm:ss followed by the given number of digits
+// (between 0 and 9) of the fraction of a second; the digits that
+// don't fit are truncated.
+func (t Time) Format(digits int) string {
+	s := fmt.Sprintf("%02d:%02d:%02d", t.Hours(), t.Minutes(), t.Seconds())
+	if digits <= 0 {
+		return s
+	}
+	if digits > 9 {
+		digits = 9
+	}
+	return s + "." + fmt.Sprintf("%09d", t.Nanoseconds())[:digits]
 }
 
 func (t Time) NativeString() string {
@@ -380,27 +653,47 @@ type Decimal struct {
 	Scale int32
 }
 
+// Parses a decimal in plain (e.g. -123.45) or exponent notation
+// (e.g. 1.2345E+2). The scale of the result is the number of digits
+// after the point minus the exponent, so 1.2E+3 has a scale of -2.
 func ParseDecimal(val string) (*Decimal, error) {
 	if val == "" {
 		return nil, fmt.Errorf("val must non empty")
 	}
-	parts := strings.Split(val, ".")
+	mantissa, exponent := val, int64(0)
+	if i := strings.IndexAny(val, "eE"); i >= 0 {
+		mantissa = val[:i]
+		exp, err := strconv.ParseInt(val[i+1:], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid decimal (invalid exponent)", val)
+		}
+		exponent = exp
+	}
+	parts := strings.Split(mantissa, ".")
 	if len(parts) > 2 {
 		return nil, fmt.Errorf("%s is not a valid decimal (too many .)", val)
 	}
 
-	scale := 0
+	digits := strings.TrimLeft(parts[0], "+-")
+	if len(parts[0])-len(digits) > 1 {
+		return nil, fmt.Errorf("val is not a valid decimal (%s)", val)
+	}
+	var scale int64
 	if len(parts) == 2 {
-		scale = len(parts[1])
+		scale = int64(len(parts[1]))
+		digits += parts[1]
 	}
+	scale -= exponent
 	if scale < math.MinInt32 || scale > math.MaxInt32 {
-		return nil, fmt.Errorf("%s is not a valid decimal (fractional part too large)", val)
+		return nil, fmt.Errorf("%s is not a valid decimal (scale out of range)", val)
 	}
-	bigint := big.NewInt(0)
-	bigint, ok := bigint.SetString(strings.Join(parts, ""), 10)
-	if !ok {
+	if digits == "" || leadingDigits(digits) != len(digits) {
 		return nil, fmt.Errorf("val is not a valid decimal (%s)", val)
 	}
+	bigint, _ := new(big.Int).SetString(digits, 10)
+	if strings.HasPrefix(mantissa, "-") {
+		bigint.Neg(bigint)
+	}
 
 	return &Decimal{bigint, int32(scale)}, nil
 }
@@ -409,10 +702,33 @@ func NewDecimal(val int64, scale int32) *Decimal {
 	return &Decimal{big.NewInt(val), scale}
 }
 
+// Returns the decimal in plain notation (e.g. -0.012) when the scale
+// is positive or zero. Decimals with a negative scale are written in
+// exponent notation (e.g. 1.2E+3 for 12 with a scale of -2), which
+// preserves the scale when they're parsed.
 func (d Decimal) String() string {
-	s := d.Value.String()
-	pos := len(s) - int(d.Scale)
-	return fmt.Sprintf("%s.%s", s[0:pos], s[pos:])
+	unscaled := d.unscaled()
+	digits := new(big.Int).Abs(unscaled).String()
+	sign := ""
+	if unscaled.Sign() < 0 {
+		sign = "-"
+	}
+
+	switch {
+	case d.Scale == 0:
+		return sign + digits
+	case d.Scale < 0:
+		exponent := int64(len(digits)-1) - int64(d.Scale)
+		coefficient := digits[:1]
+		if len(digits) > 1 {
+			coefficient += "." + digits[1:]
+		}
+		return fmt.Sprintf("%s%sE+%d", sign, coefficient, exponent)
+	case len(digits) <= int(d.Scale):
+		return sign + "0." + strings.Repeat("0", int(d.Scale)-len(digits)) + digits
+	}
+	pos := len(digits) - int(d.Scale)
+	return sign + digits[:pos] + "." + digits[pos:]
 }
 
 func (d Decimal) NativeString() string {
@@ -489,7 +805,13 @@ func (tuple Tuple) String() string {
 	return fmt.Sprintf(format, tuple.values...)
 }
 
+// Returns a representation that can be used directly in CQL, e.g.
+// (1, 'abc', null) (see CassType.Literal). Values that cannot be
+// converted to the types of the tuple are formatted with %v.
 func (tuple Tuple) NativeString() string {
+	if literal, err := tuple.kind.Literal(tuple); err == nil {
+		return literal
+	}
 	if tuple.Len() == 0 {
 		return "()"
 	}
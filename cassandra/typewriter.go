@@ -6,11 +6,14 @@ package cassandra
 // #include <cassandra.h>
 import "C"
 import (
+	"database/sql/driver"
+	"encoding"
 	"fmt"
 	"math"
 	"math/big"
 	"net"
 	"reflect"
+	"time"
 	"unsafe"
 )
 
@@ -21,6 +24,7 @@ func write(stmt *Statement, value interface{}, index int, dataType CassType) err
 		if retc := C.cass_statement_bind_null(stmt.cptr, C.size_t(index)); retc != C.CASS_OK {
 			return newError(retc)
 		}
+		return nil
 	}
 	// fmt.Printf("write(%v %T)\n", value, value)
 	tv, err := newCassTypedVal(value, dataType)
@@ -53,6 +57,11 @@ type tupleTypedVal struct {
 	kind CassType
 }
 
+// a null value, e.g. a nil pointer to a type having a codec
+type nullTypedVal struct {
+	kind CassType
+}
+
 type typedValue interface {
 	BindTo(dst interface{}, index int) error
 	Kind() CassType
@@ -61,6 +70,13 @@ type typedValue interface {
 
 func newCassTypedVal(value interface{}, dataType CassType) (typedValue, error) {
 	// fmt.Printf("write(dataType=%s)\n", dataType.String())
+	value, dataType, err := nativeValue(value, dataType)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nullTypedVal{dataType}, nil
+	}
 
 	switch dataType.primary {
 	case CASS_VALUE_TYPE_ASCII, CASS_VALUE_TYPE_TEXT, CASS_VALUE_TYPE_VARCHAR:
@@ -75,8 +91,8 @@ func newCassTypedVal(value interface{}, dataType CassType) (typedValue, error) {
 		return toSmallInt(value, dataType)
 	case CASS_VALUE_TYPE_TINY_INT:
 		return toTinyInt(value, dataType)
-	// case CASS_VALUE_TYPE_COUNTER, ,
-	// 	return readInt(value, cassType, dst)
+	case CASS_VALUE_TYPE_COUNTER:
+		return toCounter(value, dataType)
 	case CASS_VALUE_TYPE_VARINT:
 		return toVarint(value, dataType)
 	case CASS_VALUE_TYPE_FLOAT:
@@ -95,6 +111,8 @@ func newCassTypedVal(value interface{}, dataType CassType) (typedValue, error) {
 		return toUUID(value, dataType)
 	case CASS_VALUE_TYPE_INET:
 		return toInet(value, dataType)
+	case CASS_VALUE_TYPE_DURATION:
+		return toDuration(value, dataType)
 	case CASS_VALUE_TYPE_BLOB:
 		return toBlob(value, dataType)
 	case CASS_VALUE_TYPE_LIST:
@@ -109,89 +127,167 @@ func newCassTypedVal(value interface{}, dataType CassType) (typedValue, error) {
 		// 	return readUDT(value, cassType, dst)
 	}
 
+	kind, err := cassTypeOf(value)
+	if err != nil {
+		return nil, err
+	}
+	return newCassTypedVal(value, kind)
+}
+
+// Returns the Cassandra type a Go value is written as when the
+// statement doesn't provide the type of the bound values (simple
+// statements).
+func cassTypeOf(value interface{}) (CassType, error) {
 	switch value := value.(type) {
 	case bool:
-		return toBool(value, CBoolean)
+		return CBoolean, nil
 	case int64:
-		return toBigint(value, CBigInt)
+		return CBigInt, nil
 	case int32:
-		return toInt(value, CInt)
+		return CInt, nil
 	case int16:
-		return toSmallInt(value, CSmallInt)
+		return CSmallInt, nil
 	case int8:
-		return toTinyInt(value, CTinyInt)
+		return CTinyInt, nil
 	case int:
 		// must determine if it's 64 or 32
 		if value < math.MinInt32 || value > math.MaxInt32 {
-			return toBigint(value, CBigInt)
-		} else {
-			return toInt(value, CInt)
+			return CBigInt, nil
 		}
+		return CInt, nil
 	case *big.Int:
-		return toVarint(value, CVarint)
+		return CVarint, nil
 	case float32:
-		return toFloat(value, CFloat)
+		return CFloat, nil
 	case float64:
-		return toDouble(value, CDouble)
-	case *Decimal:
-		return toDecimal(value, CDecimal)
+		return CDouble, nil
+	case *Decimal, Decimal:
+		return CDecimal, nil
 	case string:
-		return toText(value, CText)
+		return CText, nil
 	case UUID:
-		switch value.Version() {
-		case 1:
-			return toUUID(value, CTimeuuid)
-		case 4:
-			return toUUID(value, CUuid)
+		if value.Version() == 1 {
+			return CTimeuuid, nil
 		}
+		return CUuid, nil
 	case Date:
-		return toDate(value, CDate)
+		return CDate, nil
 	case Time:
-		return toTime(value, CTime)
-	case Timestamp:
-		return toTimestamp(value, CTimestamp)
+		return CTime, nil
+	case Timestamp, time.Time:
+		return CTimestamp, nil
+	case Duration, *Duration:
+		return CDuration, nil
 	case net.IP:
-		return toInet(value, CInet)
+		return CInet, nil
 	case []byte:
-		return toBlob(value, CBlob)
-	case setmarker:
-		return toSet(value.value, CSet)
+		return CBlob, nil
+	case setmarker, anySet:
+		return CSet, nil
 	case Tuple, *Tuple:
-		return toTuple(value, CTuple)
+		return CTuple, nil
 	}
 	// last attempt
 	rVal := reflect.ValueOf(value)
 	switch rVal.Type().Kind() {
 	case reflect.Bool:
-		return toBool(value, CBoolean)
+		return CBoolean, nil
 	case reflect.Int64:
-		return toBigint(value, CBigInt)
+		return CBigInt, nil
 	case reflect.Int32:
-		return toInt(value, CInt)
+		return CInt, nil
 	case reflect.Int16:
-		return toSmallInt(value, CSmallInt)
+		return CSmallInt, nil
 	case reflect.Int8:
-		return toTinyInt(value, CTinyInt)
+		return CTinyInt, nil
 	case reflect.Int:
-		// if maxInt > 2147483647 {
 		if rVal.Int() < math.MinInt32 || rVal.Int() > math.MaxInt32 {
-			return toBigint(rVal.Int(), CBigInt)
-		} else {
-			return toInt(rVal.Int(), CInt)
+			return CBigInt, nil
 		}
+		return CInt, nil
 	case reflect.Float32:
-		return toFloat(value, CFloat)
+		return CFloat, nil
 	case reflect.Float64:
-		return toDouble(value, CDouble)
+		return CDouble, nil
 	case reflect.String:
-		return toText(value, CText)
+		return CText, nil
 	case reflect.Map:
-		return toMap(value, CMap)
+		return CMap, nil
 	case reflect.Slice, reflect.Array:
-		return toList(value, CList)
+		return CList, nil
 	}
 
-	return nil, fmt.Errorf("unknown type %T", value)
+	return CUnknown, fmt.Errorf("unknown type %T", value)
+}
+
+// Returns the native value bound for a value converted by a codec, a
+// Null* type, a driver.Valuer (e.g. a type used with database/sql) or
+// an encoding.TextMarshaler (whose text is converted to the type of
+// the column if it isn't text), or the value itself. The native value
+// is nil for null. Returns the type the value is bound as, which is
+// the codec's when the statement doesn't provide it.
+func nativeValue(value interface{}, dataType CassType) (interface{}, CassType, error) {
+	for value != nil {
+		if codec, v, ok := codecForValue(value); ok {
+			if dataType.Equals(CUnknown) {
+				dataType = codec.CassType()
+			}
+			if v == nil {
+				return nil, dataType, nil
+			}
+			native, err := codec.Encode(v)
+			if err != nil {
+				return nil, dataType, err
+			}
+			if native != nil && reflect.TypeOf(native) == reflect.TypeOf(v) {
+				return nil, dataType, fmt.Errorf("codec for %T must encode values into a different type",
+					v)
+			}
+			value = native
+			continue
+		}
+		if n, ok := value.(nullable); ok {
+			if rVal := reflect.ValueOf(n); rVal.Kind() == reflect.Ptr && rVal.IsNil() {
+				return nil, dataType, nil
+			}
+			v, valid := n.nullValue()
+			if !valid {
+				return nil, dataType, nil
+			}
+			value = v
+			continue
+		}
+		if isBuiltinType(reflect.TypeOf(value)) {
+			break
+		}
+		switch v := value.(type) {
+		case driver.Valuer:
+			if rVal := reflect.ValueOf(v); rVal.Kind() == reflect.Ptr && rVal.IsNil() {
+				return nil, dataType, nil
+			}
+			native, err := v.Value()
+			if err != nil {
+				return nil, dataType, err
+			}
+			if native != nil && reflect.TypeOf(native) == reflect.TypeOf(v) {
+				return nil, dataType, fmt.Errorf("%T.Value() must return a different type", v)
+			}
+			value = native
+			continue
+		case encoding.TextMarshaler:
+			if rVal := reflect.ValueOf(v); rVal.Kind() == reflect.Ptr && rVal.IsNil() {
+				return nil, dataType, nil
+			}
+			text, err := v.MarshalText()
+			if err != nil {
+				return nil, dataType, err
+			}
+			value = string(text)
+			continue
+		}
+		break
+	}
+	return value, dataType, nil
 }
 
 func toBool(value interface{}, cassType CassType) (*primitiveTypedVal, error) {
@@ -225,8 +321,22 @@ func toBigint(value interface{}, cassType CassType) (*primitiveTypedVal, error)
 
 	rVal := reflect.ValueOf(value)
 	switch rVal.Type().Kind() {
-	case reflect.Int64:
+	case reflect.Int64, reflect.Int:
+		return &primitiveTypedVal{rVal.Int(), cassType}, nil
+	}
+
+	return nil, fmt.Errorf("cannot convert %T into %s", value, cassType.String())
+}
+
+// Counters are 64 bit but are usually updated with small deltas,
+// so any Go integer is accepted.
+func toCounter(value interface{}, cassType CassType) (*primitiveTypedVal, error) {
+	rVal := reflect.ValueOf(value)
+	switch rVal.Type().Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
 		return &primitiveTypedVal{rVal.Int(), cassType}, nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &primitiveTypedVal{int64(rVal.Uint()), cassType}, nil
 	}
 
 	return nil, fmt.Errorf("cannot convert %T into %s", value, cassType.String())
@@ -234,14 +344,17 @@ func toBigint(value interface{}, cassType CassType) (*primitiveTypedVal, error)
 
 func toInt(value interface{}, cassType CassType) (*primitiveTypedVal, error) {
 	switch value := value.(type) {
-	case int32, int:
+	case int32:
 		return &primitiveTypedVal{value, cassType}, nil
 	}
 
 	rVal := reflect.ValueOf(value)
 	switch rVal.Type().Kind() {
 	case reflect.Int32, reflect.Int:
-		return &primitiveTypedVal{rVal.Int(), cassType}, nil
+		if rVal.Int() < math.MinInt32 || rVal.Int() > math.MaxInt32 {
+			return nil, fmt.Errorf("cannot convert %T (%d) into %s", value, rVal.Int(), cassType.String())
+		}
+		return &primitiveTypedVal{int32(rVal.Int()), cassType}, nil
 	}
 
 	return nil, fmt.Errorf("cannot convert %T into %s", value, cassType.String())
@@ -321,15 +434,31 @@ func toDecimal(value interface{}, cassType CassType) (*primitiveTypedVal, error)
 	switch value := value.(type) {
 	case *Decimal:
 		return &primitiveTypedVal{value, cassType}, nil
+	case Decimal:
+		return &primitiveTypedVal{&value, cassType}, nil
+	case string:
+		d, err := ParseDecimal(value)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert %T (%s) into %s",
+				value, value, cassType.String())
+		}
+		return &primitiveTypedVal{d, cassType}, nil
 	}
 
 	return nil, fmt.Errorf("cannot convert %T into %s", value, cassType.String())
 }
 
-// Could allow string too
 func toUUID(value interface{}, cassType CassType) (*primitiveTypedVal, error) {
-	if value, ok := value.(UUID); ok {
+	switch value := value.(type) {
+	case UUID:
 		return &primitiveTypedVal{value, cassType}, nil
+	case string:
+		u, err := ParseUUID(value)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert %T (%s) into %s",
+				value, value, cassType.String())
+		}
+		return &primitiveTypedVal{u, cassType}, nil
 	}
 
 	return nil, fmt.Errorf("cannot convert %T into %s", value, cassType.String())
@@ -354,6 +483,14 @@ func toDate(value interface{}, cassType CassType) (*primitiveTypedVal, error) {
 	switch value := value.(type) {
 	case Date:
 		return &primitiveTypedVal{value.days, cassType}, nil
+	case time.Time:
+		year, month, day := value.Date()
+		date, err := newDate(int64(year), int64(month), int64(day))
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert %s into %s: %s",
+				value, cassType.String(), err.Error())
+		}
+		return &primitiveTypedVal{date.days, cassType}, nil
 	case uint32:
 		return &primitiveTypedVal{value, cassType}, nil
 	case string:
@@ -380,6 +517,13 @@ func toTime(value interface{}, cassType CassType) (*primitiveTypedVal, error) {
 		return &primitiveTypedVal{int64(value), cassType}, nil
 	case int64:
 		return &primitiveTypedVal{value, cassType}, nil
+	case string:
+		t, err := ParseTime(value)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert %T (%s) into %s",
+				value, value, cassType.String())
+		}
+		return &primitiveTypedVal{int64(t), cassType}, nil
 	}
 
 	rVal := reflect.ValueOf(value)
@@ -395,8 +539,17 @@ func toTimestamp(value interface{}, cassType CassType) (*primitiveTypedVal, erro
 	switch value := value.(type) {
 	case Timestamp:
 		return &primitiveTypedVal{value.secondsSinceEpoch, cassType}, nil
+	case time.Time:
+		return &primitiveTypedVal{NewTimestampFromTime(value).secondsSinceEpoch, cassType}, nil
 	case int64:
 		return &primitiveTypedVal{value, cassType}, nil
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert %T (%s) into %s",
+				value, value, cassType.String())
+		}
+		return &primitiveTypedVal{NewTimestampFromTime(t).secondsSinceEpoch, cassType}, nil
 	}
 
 	rVal := reflect.ValueOf(value)
@@ -408,10 +561,35 @@ func toTimestamp(value interface{}, cassType CassType) (*primitiveTypedVal, erro
 	return nil, fmt.Errorf("cannot convert %T into %s", value, cassType.String())
 }
 
+func toDuration(value interface{}, cassType CassType) (*primitiveTypedVal, error) {
+	var d Duration
+	switch value := value.(type) {
+	case Duration:
+		d = value
+	case *Duration:
+		d = *value
+	case time.Duration:
+		d = FromDuration(value)
+	default:
+		return nil, fmt.Errorf("cannot convert %T into %s", value, cassType.String())
+	}
+	if err := d.validate(); err != nil {
+		return nil, err
+	}
+	return &primitiveTypedVal{d, cassType}, nil
+}
+
 func toInet(value interface{}, cassType CassType) (*primitiveTypedVal, error) {
 	switch value := value.(type) {
 	case net.IP:
 		return &primitiveTypedVal{[]byte(value), cassType}, nil
+	case string:
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return nil, fmt.Errorf("cannot convert %T (%s) into %s",
+				value, value, cassType.String())
+		}
+		return &primitiveTypedVal{[]byte(ip), cassType}, nil
 	}
 
 	return nil, fmt.Errorf("cannot convert %T into %s", value, cassType.String())
@@ -435,38 +613,57 @@ func toBlob(value interface{}, cassType CassType) (*primitiveTypedVal, error) {
 }
 
 func toTuple(value interface{}, dataType CassType) (*tupleTypedVal, error) {
+	var tuple *Tuple
 	switch value := value.(type) {
 	case *Tuple:
-		sz := len(value.Kind().subtypes)
-		// FIXME: cptr must be freed C.cass_tuple_free(cptr)
-		cptr := C.cass_tuple_new(C.size_t(sz))
-		ttv := &tupleTypedVal{cptr, dataType}
+		tuple = value
+	case Tuple:
+		tuple = &value
+	default:
+		return nil, fmt.Errorf("cannot convert %T into %s", value, dataType.String())
+	}
 
-		tupleVals := value.Values()
+	// the column metadata, when available, is more accurate than the
+	// types the tuple was created with
+	subtypes := tuple.Kind().subtypes
+	if len(dataType.subtypes) > 0 {
+		subtypes = dataType.subtypes
+	} else {
+		dataType = tuple.Kind()
+	}
+	if len(tuple.Values()) > len(subtypes) {
+		return nil, fmt.Errorf("cannot convert a tuple with %d values into %s",
+			len(tuple.Values()), dataType.String())
+	}
 
-		for idx := 0; idx < sz; idx++ {
-			if idx >= len(tupleVals) {
-				C.cass_tuple_set_null(cptr, C.size_t(idx))
-				continue
-			}
-			v := value.Get(idx)
-			if v == nil {
-				C.cass_tuple_set_null(cptr, C.size_t(idx))
-				continue
-			}
-			tv, err := newCassTypedVal(v, value.Kind().subtypes[idx])
-			if err != nil {
-				return nil, err
-			}
-			defer tv.Free()
-			if err = tv.BindTo(ttv, idx); err != nil {
-				return nil, err
-			}
+	sz := len(subtypes)
+	ttv := &tupleTypedVal{C.cass_tuple_new(C.size_t(sz)), dataType}
+	for idx := 0; idx < sz; idx++ {
+		var v interface{}
+		if idx < len(tuple.Values()) {
+			v = tuple.Get(idx)
+		}
+		if v == nil {
+			C.cass_tuple_set_null(ttv.cptr, C.size_t(idx))
+			continue
+		}
+		if _, err := bindElement(ttv, idx, v, subtypes[idx]); err != nil {
+			ttv.Free()
+			return nil, err
 		}
-		return ttv, nil
 	}
+	return ttv, nil
+}
 
-	return nil, fmt.Errorf("cannot convert %T into %s", value, dataType.String())
+// Converts an element of a collection or tuple and binds it to its
+// container. Returns the type of the converted element.
+func bindElement(dst typedValue, index int, value interface{}, dataType CassType) (CassType, error) {
+	tv, err := newCassTypedVal(value, dataType)
+	if err != nil {
+		return dataType, err
+	}
+	defer tv.Free()
+	return tv.Kind(), tv.BindTo(dst, index)
 }
 
 func toList(value interface{}, dataType CassType) (*collectionTypedVal, error) {
@@ -481,21 +678,16 @@ func toList(value interface{}, dataType CassType) (*collectionTypedVal, error) {
 			elemDataType = dataType.subtypes[0]
 		}
 
-		idx := 0
-		for idx < rVal.Len() {
-			tv, err := newCassTypedVal(rVal.Index(idx).Interface(), elemDataType)
+		for idx := 0; idx < rVal.Len(); idx++ {
+			kind, err := bindElement(ctv, -1, rVal.Index(idx).Interface(), elemDataType)
 			if err != nil {
+				ctv.Free()
 				return nil, err
 			}
-			defer tv.Free()
 			if elemDataType.Equals(CUnknown) {
-				elemDataType = tv.Kind()
+				elemDataType = kind
 				ctv.kind = ctv.kind.Specialize(elemDataType)
 			}
-			if err = tv.BindTo(ctv, -1); err != nil {
-				return nil, err
-			}
-			idx += 1
 		}
 		return ctv, nil
 	}
@@ -512,38 +704,30 @@ func toMap(value interface{}, dataType CassType) (*collectionTypedVal, error) {
 	ctv := &collectionTypedVal{col, dataType, CASS_VALUE_TYPE_MAP}
 
 	var keyDataType, valDataType CassType = CUnknown, CUnknown
-	if len(dataType.subtypes) > 0 {
-		// keyDataType = cassDataType(C.cass_data_type_sub_data_type(dataType, 0))
-		// valDataType = cassDataType(C.cass_data_type_sub_data_type(dataType, 1))
+	if len(dataType.subtypes) > 1 {
 		keyDataType = dataType.subtypes[0]
 		valDataType = dataType.subtypes[1]
 	}
 
 	keys := rVal.MapKeys()
 	for _, key := range keys {
-		tv, err := newCassTypedVal(key.Interface(), keyDataType)
+		kind, err := bindElement(ctv, -1, key.Interface(), keyDataType)
 		if err != nil {
+			ctv.Free()
 			return nil, err
 		}
-		defer tv.Free()
 		if keyDataType.Equals(CUnknown) {
-			keyDataType = tv.Kind()
+			keyDataType = kind
 		}
-		if err = tv.BindTo(ctv, -1); err != nil {
-			return nil, err
-		}
-		tv, err = newCassTypedVal(rVal.MapIndex(key).Interface(), valDataType)
+		kind, err = bindElement(ctv, -1, rVal.MapIndex(key).Interface(), valDataType)
 		if err != nil {
+			ctv.Free()
 			return nil, err
 		}
-		defer tv.Free()
 		if valDataType.Equals(CUnknown) {
-			valDataType = tv.Kind()
+			valDataType = kind
 			ctv.kind = ctv.kind.Specialize(keyDataType, valDataType)
 		}
-		if err = tv.BindTo(ctv, -1); err != nil {
-			return nil, err
-		}
 	}
 
 	return ctv, nil
@@ -555,72 +739,41 @@ func toSet(value interface{}, dataType CassType) (*collectionTypedVal, error) {
 		return toSet(value.value, dataType)
 	}
 
+	var elems []reflect.Value
 	rVal := reflect.ValueOf(value)
 	switch rVal.Type().Kind() {
 	case reflect.Slice, reflect.Array:
-		col := C.cass_collection_new(C.CASS_COLLECTION_TYPE_SET, C.size_t(rVal.Len()))
-		ctv := &collectionTypedVal{col, dataType, CASS_VALUE_TYPE_SET}
-
-		// var elemDataType cassDataType = nil
-		// if dataType != nil {
-		// 	elemDataType = cassDataType(C.cass_data_type_sub_data_type(dataType, 0))
-		// }
-		elemDataType := CUnknown
-		if len(dataType.subtypes) > 0 {
-			elemDataType = dataType.subtypes[0]
+		elems = make([]reflect.Value, rVal.Len())
+		for idx := range elems {
+			elems[idx] = rVal.Index(idx)
 		}
+	case reflect.Map:
+		elems = rVal.MapKeys()
+	default:
+		return nil, fmt.Errorf("cannot convert %T into %s", value, dataType.String())
+	}
 
-		idx := 0
-		for idx < rVal.Len() {
-			tv, err := newCassTypedVal(rVal.Index(idx).Interface(), elemDataType)
-			if err != nil {
-				return nil, err
-			}
-			defer tv.Free()
-			if elemDataType.Equals(CUnknown) {
-				elemDataType = tv.Kind()
-				ctv.kind = ctv.kind.Specialize(elemDataType)
-			}
-			if err = tv.BindTo(ctv, -1); err != nil {
-				return nil, err
-			}
-			idx += 1
-		}
+	col := C.cass_collection_new(C.CASS_COLLECTION_TYPE_SET, C.size_t(len(elems)))
+	ctv := &collectionTypedVal{col, dataType, CASS_VALUE_TYPE_SET}
 
-		return ctv, nil
-	case reflect.Map:
-		col := C.cass_collection_new(C.CASS_COLLECTION_TYPE_SET, C.size_t(rVal.Len()))
-		ctv := &collectionTypedVal{col, dataType, CASS_VALUE_TYPE_SET}
+	elemDataType := CUnknown
+	if len(dataType.subtypes) > 0 {
+		elemDataType = dataType.subtypes[0]
+	}
 
-		// var elemDataType cassDataType = nil
-		// if dataType != nil {
-		// 	elemDataType = cassDataType(C.cass_data_type_sub_data_type(dataType, 0))
-		// }
-		elemDataType := CUnknown
-		if len(dataType.subtypes) > 0 {
-			elemDataType = dataType.subtypes[0]
+	for _, elem := range elems {
+		kind, err := bindElement(ctv, -1, elem.Interface(), elemDataType)
+		if err != nil {
+			ctv.Free()
+			return nil, err
 		}
-
-		keys := rVal.MapKeys()
-		for _, key := range keys {
-			tv, err := newCassTypedVal(key.Interface(), elemDataType)
-			if err != nil {
-				return nil, err
-			}
-			defer tv.Free()
-			if elemDataType.Equals(CUnknown) {
-				elemDataType = tv.Kind()
-				ctv.kind = ctv.kind.Specialize(elemDataType)
-			}
-			if err = tv.BindTo(ctv, -1); err != nil {
-				return nil, err
-			}
+		if elemDataType.Equals(CUnknown) {
+			elemDataType = kind
+			ctv.kind = ctv.kind.Specialize(elemDataType)
 		}
-
-		return ctv, nil
 	}
 
-	return nil, fmt.Errorf("cannot convert %T into %s", value, dataType.String())
+	return ctv, nil
 }
 
 // implements internal `typedValue` interface
@@ -630,6 +783,8 @@ func (ctv collectionTypedVal) BindTo(dst interface{}, index int) error {
 	switch dst := dst.(type) {
 	case *Statement:
 		retc = C.cass_statement_bind_collection(dst.cptr, pos, ctv.cptr)
+	case *collectionTypedVal:
+		retc = C.cass_collection_append_collection(dst.cptr, ctv.cptr)
 	case *tupleTypedVal:
 		retc = C.cass_tuple_set_collection(dst.cptr, pos, ctv.cptr)
 	}
@@ -674,6 +829,30 @@ func (ttv tupleTypedVal) Free() {
 	C.cass_tuple_free(ttv.cptr)
 }
 
+func (ntv nullTypedVal) BindTo(dst interface{}, index int) error {
+	var retc C.CassError
+	pos := C.size_t(index)
+	switch dst := dst.(type) {
+	case *Statement:
+		retc = C.cass_statement_bind_null(dst.cptr, pos)
+	case *collectionTypedVal:
+		return fmt.Errorf("%s collections cannot contain null values", dst.kind.String())
+	case *tupleTypedVal:
+		retc = C.cass_tuple_set_null(dst.cptr, pos)
+	}
+	if retc != C.CASS_OK {
+		return newError(retc)
+	}
+	return nil
+}
+
+func (ntv nullTypedVal) Kind() CassType {
+	return ntv.kind
+}
+
+func (ntv nullTypedVal) Free() {
+}
+
 // implements internal `typedValue` interface
 func (ptv primitiveTypedVal) BindTo(dst interface{}, index int) error {
 	var retc C.CassError
@@ -703,7 +882,8 @@ func (ptv primitiveTypedVal) BindTo(dst interface{}, index int) error {
 			retc = C.cass_tuple_set_bool(dst.cptr, pos, val)
 		}
 		// int types (not yet VARINT)
-	case CASS_VALUE_TYPE_BIGINT, CASS_VALUE_TYPE_TIMESTAMP, CASS_VALUE_TYPE_TIME:
+	case CASS_VALUE_TYPE_BIGINT, CASS_VALUE_TYPE_COUNTER,
+		CASS_VALUE_TYPE_TIMESTAMP, CASS_VALUE_TYPE_TIME:
 		val := C.cass_int64_t(ptv.val.(int64))
 		switch dst := dst.(type) {
 		case *Statement:
@@ -816,6 +996,19 @@ func (ptv primitiveTypedVal) BindTo(dst interface{}, index int) error {
 		case *tupleTypedVal:
 			retc = C.cass_tuple_set_uint32(dst.cptr, pos, val)
 		}
+	case CASS_VALUE_TYPE_DURATION:
+		val := ptv.val.(Duration)
+		months := C.cass_int32_t(val.Months)
+		days := C.cass_int32_t(val.Days)
+		nanos := C.cass_int64_t(val.Nanoseconds)
+		switch dst := dst.(type) {
+		case *Statement:
+			retc = C.cass_statement_bind_duration(dst.cptr, pos, months, days, nanos)
+		case *collectionTypedVal:
+			retc = C.cass_collection_append_duration(dst.cptr, months, days, nanos)
+		case *tupleTypedVal:
+			retc = C.cass_tuple_set_duration(dst.cptr, pos, months, days, nanos)
+		}
 	case CASS_VALUE_TYPE_INET:
 		val := ptv.val.([]byte)
 		var cInet C.struct_CassInet_
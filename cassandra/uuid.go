@@ -0,0 +1,207 @@
+package cassandra
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"net"
+	"sync"
+	"time"
+)
+
+// number of 100ns intervals between the start of the Gregorian
+// calendar (1582-10-15), used by UUID v1, and the Unix epoch
+const uuidEpochOffset = 0x01B21DD213814000
+
+// the clock sequence and node of the UUIDs returned by MinTimeUUID
+// and MaxTimeUUID, which are the smallest and greatest values in
+// Cassandra's timeuuid ordering (they're compared as signed bytes)
+const (
+	minClockSeqAndNode = 0x8080808080808080
+	maxClockSeqAndNode = 0x7f7f7f7f7f7f7f7f
+)
+
+type timeUUIDGenerator struct {
+	sync.Mutex
+	initialized bool
+	lastTicks   uint64
+	clockSeq    uint16
+	node        [6]byte
+}
+
+var timeUUIDGen timeUUIDGenerator
+
+// Returns a new random (version 4) UUID. It panics if the system's
+// secure random number generator fails.
+func NewRandomUUID() UUID {
+	var u UUID
+	randomBytes(u[:])
+	u[6] = (u[6] & 0x0f) | 0x40
+	u[8] = (u[8] & 0x3f) | 0x80
+	return u
+}
+
+// Returns a new time-based (version 1) UUID for the current time.
+// The node is the hardware address of one of the network interfaces
+// (or a random multicast address if there's none) and the clock
+// sequence is chosen randomly once per process. The UUIDs generated
+// by a process are unique and strictly increasing.
+func NewTimeUUID() UUID {
+	gen := &timeUUIDGen
+	gen.Lock()
+	defer gen.Unlock()
+
+	gen.initialize()
+	ticks := timeToTicks(time.Now())
+	// keep the timestamps unique and monotonic if the clock is too
+	// coarse or moves backwards
+	if ticks <= gen.lastTicks {
+		ticks = gen.lastTicks + 1
+	}
+	gen.lastTicks = ticks
+
+	return newTimeUUID(ticks, gen.clockSeq, gen.node)
+}
+
+// Returns a time-based (version 1) UUID holding the given time, with
+// the node and clock sequence used by NewTimeUUID. Unlike NewTimeUUID
+// the result is not guaranteed to be unique.
+func TimeUUIDFromTime(t time.Time) UUID {
+	gen := &timeUUIDGen
+	gen.Lock()
+	defer gen.Unlock()
+
+	gen.initialize()
+	return newTimeUUID(timeToTicks(t), gen.clockSeq, gen.node)
+}
+
+// Returns the smallest timeuuid for the millisecond of t, like the
+// CQL minTimeuuid() function. Together with MaxTimeUUID it allows
+// querying timeuuid columns by time range, binding MinTimeUUID(start)
+// and MaxTimeUUID(end) to "WHERE id > ? AND id < ?". The result must
+// not be inserted as it's not a valid UUID.
+func MinTimeUUID(t time.Time) UUID {
+	ticks := timeToTicks(t.Truncate(time.Millisecond))
+	return timeUUIDWithLSB(ticks, minClockSeqAndNode)
+}
+
+// Returns the greatest timeuuid for the millisecond of t, like the
+// CQL maxTimeuuid() function (see MinTimeUUID).
+func MaxTimeUUID(t time.Time) UUID {
+	ticks := timeToTicks(t.Truncate(time.Millisecond)) + 9999
+	return timeUUIDWithLSB(ticks, maxClockSeqAndNode)
+}
+
+// Returns the time embedded in a time-based (version 1) UUID with
+// a precision of 100ns. It returns the zero time.Time for the other
+// versions.
+func (u UUID) Time() time.Time {
+	if u.Version() != 1 {
+		return time.Time{}
+	}
+	ticks := int64(u.ticks()) - uuidEpochOffset
+	return time.Unix(ticks/1e7, (ticks%1e7)*100).UTC()
+}
+
+// Compares two UUIDs the way Cassandra orders them and returns
+// -1, 0 or +1. UUIDs are ordered by version first. Time-based UUIDs
+// are then ordered by their timestamp and the others by their most
+// significant bytes. Ties are broken by comparing the least
+// significant bytes as signed bytes.
+func (u UUID) Compare(other UUID) int {
+	if v1, v2 := u.Version(), other.Version(); v1 != v2 {
+		return compareUint(uint64(v1), uint64(v2))
+	}
+	if u.Version() == 1 {
+		if c := compareUint(u.ticks(), other.ticks()); c != 0 {
+			return c
+		}
+	} else if c := bytes.Compare(u[:8], other[:8]); c != 0 {
+		return c
+	}
+	for i := 8; i < 16; i++ {
+		if b1, b2 := int8(u[i]), int8(other[i]); b1 != b2 {
+			if b1 < b2 {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// returns the 60 bit timestamp of a version 1 UUID
+func (u UUID) ticks() uint64 {
+	low := uint64(binary.BigEndian.Uint32(u[0:4]))
+	mid := uint64(binary.BigEndian.Uint16(u[4:6]))
+	high := uint64(binary.BigEndian.Uint16(u[6:8]) & 0x0fff)
+	return high<<48 | mid<<32 | low
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func timeToTicks(t time.Time) uint64 {
+	return uint64(t.UnixNano()/100 + uuidEpochOffset)
+}
+
+func newTimeUUID(ticks uint64, clockSeq uint16, node [6]byte) UUID {
+	var lsb [8]byte
+	binary.BigEndian.PutUint16(lsb[0:2], clockSeq&0x3fff|0x8000)
+	copy(lsb[2:], node[:])
+	return timeUUIDWithLSB(ticks, binary.BigEndian.Uint64(lsb[:]))
+}
+
+func timeUUIDWithLSB(ticks uint64, lsb uint64) UUID {
+	var u UUID
+	binary.BigEndian.PutUint32(u[0:4], uint32(ticks))
+	binary.BigEndian.PutUint16(u[4:6], uint16(ticks>>32))
+	binary.BigEndian.PutUint16(u[6:8], uint16(ticks>>48)&0x0fff|0x1000)
+	binary.BigEndian.PutUint64(u[8:16], lsb)
+	return u
+}
+
+// must be called with the generator's lock held
+func (gen *timeUUIDGenerator) initialize() {
+	if gen.initialized {
+		return
+	}
+	var seq [2]byte
+	randomBytes(seq[:])
+	gen.clockSeq = binary.BigEndian.Uint16(seq[:]) & 0x3fff
+
+	if addr, ok := hardwareAddr(); ok {
+		copy(gen.node[:], addr)
+	} else {
+		randomBytes(gen.node[:])
+		// random node ids have the multicast bit set (RFC 4122 4.5)
+		gen.node[0] |= 0x01
+	}
+	gen.initialized = true
+}
+
+func randomBytes(b []byte) {
+	if _, err := rand.Read(b); err != nil {
+		panic("cassandra: cannot generate random bytes: " + err.Error())
+	}
+}
+
+func hardwareAddr() ([]byte, bool) {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, false
+	}
+	for _, i := range interfaces {
+		if i.Flags&net.FlagLoopback == 0 && len(i.HardwareAddr) == 6 {
+			return i.HardwareAddr, true
+		}
+	}
+	return nil, false
+}
@@ -4,6 +4,7 @@ import (
 	"golang-driver/cassandra"
 	"golang-driver/cassandra/test"
 	"testing"
+	"time"
 )
 
 const (
@@ -69,6 +70,94 @@ func testSelect(t *testing.T, session *cassandra.Session) {
 	}
 }
 
+func TestNewRandomUUID(t *testing.T) {
+	u1, u2 := cassandra.NewRandomUUID(), cassandra.NewRandomUUID()
+	if u1.Version() != 4 {
+		t.Errorf("expected version 4, got %d", u1.Version())
+	}
+	if u1[8]&0xc0 != 0x80 {
+		t.Errorf("expected the RFC 4122 variant in %s", u1)
+	}
+	if u1 == u2 {
+		t.Errorf("random UUIDs should differ (%s)", u1)
+	}
+	if _, err := cassandra.ParseUUID(u1.String()); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNewTimeUUID(t *testing.T) {
+	before := time.Now().Add(-time.Millisecond)
+	prev := cassandra.NewTimeUUID()
+	for i := 0; i < 1000; i++ {
+		u := cassandra.NewTimeUUID()
+		if u.Version() != 1 {
+			t.Fatalf("expected version 1, got %d", u.Version())
+		}
+		if u.Compare(prev) <= 0 {
+			t.Fatalf("%s should be greater than %s", u, prev)
+		}
+		prev = u
+	}
+	if ts := prev.Time(); ts.Before(before) || ts.After(time.Now().Add(time.Millisecond)) {
+		t.Errorf("unexpected time %s in %s", ts, prev)
+	}
+}
+
+func TestTimeUUIDFromTime(t *testing.T) {
+	ts := time.Date(2015, 12, 18, 10, 11, 12, 123456700, time.UTC)
+	u := cassandra.TimeUUIDFromTime(ts)
+	if !u.Time().Equal(ts) {
+		t.Errorf("expected %s, got %s", ts, u.Time())
+	}
+
+	known, _ := cassandra.ParseUUID(timeuuid_)
+	expected := time.Date(2015, 12, 18, 5, 31, 42, 509000000, time.UTC)
+	if !known.Time().Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, known.Time())
+	}
+
+	random, _ := cassandra.ParseUUID(uuid_)
+	if !random.Time().IsZero() {
+		t.Errorf("a version 4 UUID has no time (got %s)", random.Time())
+	}
+}
+
+func TestMinMaxTimeUUID(t *testing.T) {
+	ts := time.Date(2015, 12, 18, 10, 11, 12, 123456789, time.UTC)
+	min, max := cassandra.MinTimeUUID(ts), cassandra.MaxTimeUUID(ts)
+	if min.String() != "aa4464b0-a56f-11e5-8080-808080808080" {
+		t.Errorf("unexpected minimum %s", min)
+	}
+	if max.String() != "aa448bbf-a56f-11e5-7f7f-7f7f7f7f7f7f" {
+		t.Errorf("unexpected maximum %s", max)
+	}
+
+	u := cassandra.TimeUUIDFromTime(ts)
+	if min.Compare(u) >= 0 || max.Compare(u) <= 0 {
+		t.Errorf("%s should be between %s and %s", u, min, max)
+	}
+	next := cassandra.MinTimeUUID(ts.Add(time.Millisecond))
+	if max.Compare(next) >= 0 {
+		t.Errorf("%s should be less than %s", max, next)
+	}
+}
+
+func TestUUIDCompare(t *testing.T) {
+	earlier := cassandra.TimeUUIDFromTime(time.Unix(1000, 0))
+	later := cassandra.TimeUUIDFromTime(time.Unix(2000, 0))
+	random := cassandra.NewRandomUUID()
+	if earlier.Compare(later) != -1 || later.Compare(earlier) != 1 {
+		t.Errorf("%s should be ordered before %s", earlier, later)
+	}
+	if earlier.Compare(earlier) != 0 {
+		t.Errorf("%s should be equal to itself", earlier)
+	}
+	if later.Compare(random) != -1 {
+		t.Errorf("version 1 UUIDs should be ordered before version 4 ones")
+	}
+}
+
 var (
 	uuidSetup = []string{
 		"CREATE KEYSPACE IF NOT EXISTS golang_driver WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};",
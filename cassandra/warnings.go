@@ -0,0 +1,136 @@
+package cassandra
+
+// #cgo LDFLAGS: -L/usr/local/lib -lcassandra
+// #cgo CFLAGS: -I/usr/local/include
+// #include <stdlib.h>
+// #include <cassandra.h>
+import "C"
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// The C/C++ driver doesn't expose the warnings of a response through
+// its API, it only logs them. The driver's log callback is replaced
+// with one that keeps the default logging to stderr and hands every
+// server-side warning to the requests that are in flight, and to the
+// warning handlers of their sessions.
+const serverWarningPrefix = "Server-side warning: "
+
+//export goLogCallback
+func goLogCallback(message *C.CassLogMessage, data unsafe.Pointer) {
+	msg := C.GoString(&message.message[0])
+	fmt.Fprintf(os.Stderr, "%d.%03d [%s] (%s:%d:%s): %s\n",
+		uint64(message.time_ms)/1000, uint64(message.time_ms)%1000,
+		C.GoString(C.cass_log_level_string(message.severity)),
+		C.GoString(message.file), int(message.line),
+		C.GoString(message.function), msg)
+
+	if strings.HasPrefix(msg, serverWarningPrefix) {
+		inflight.add(strings.TrimPrefix(msg, serverWarningPrefix))
+	}
+}
+
+// Collects the server warnings logged while a request is in flight.
+// As the logged warnings cannot be tied to a specific request, a
+// warning is handed to all the requests in flight when it was logged.
+type warningCollector struct {
+	sync.Mutex
+	session  *Session
+	warnings []string
+}
+
+var inflight = &inflightRequests{
+	collectors: make(map[*warningCollector]struct{}),
+}
+
+type inflightRequests struct {
+	sync.Mutex
+	collectors map[*warningCollector]struct{}
+}
+
+func (r *inflightRequests) track(session *Session) *warningCollector {
+	wc := &warningCollector{session: session}
+	r.Lock()
+	r.collectors[wc] = struct{}{}
+	r.Unlock()
+	return wc
+}
+
+func (r *inflightRequests) untrack(wc *warningCollector) []string {
+	r.Lock()
+	delete(r.collectors, wc)
+	r.Unlock()
+
+	wc.Lock()
+	defer wc.Unlock()
+	return wc.warnings
+}
+
+// Hands the warning to the requests in flight, and queues a call of
+// the warning handler of each of their sessions.
+func (r *inflightRequests) add(warning string) {
+	var handlers []func(warning string)
+	sessions := make(map[*Session]bool)
+	r.Lock()
+	for wc := range r.collectors {
+		wc.Lock()
+		wc.warnings = append(wc.warnings, warning)
+		wc.Unlock()
+		if wc.session == nil || sessions[wc.session] {
+			continue
+		}
+		sessions[wc.session] = true
+		if handler := wc.session.handler(); handler != nil {
+			handlers = append(handlers, handler)
+		}
+	}
+	r.Unlock()
+
+	if len(handlers) > 0 {
+		warningHandlers.queue(handlers, warning)
+	}
+}
+
+// Calls the warning handlers from a goroutine rather than from the
+// driver's logging thread, which must not wait for them. The calls
+// are queued without limit and made one at a time, in the order the
+// warnings were logged.
+type handlerQueue struct {
+	sync.Mutex
+	calls []func()
+	ready chan struct{}
+	start sync.Once
+}
+
+var warningHandlers = &handlerQueue{ready: make(chan struct{}, 1)}
+
+func (q *handlerQueue) queue(handlers []func(warning string), warning string) {
+	q.Lock()
+	for _, handler := range handlers {
+		handler := handler
+		q.calls = append(q.calls, func() { handler(warning) })
+	}
+	q.Unlock()
+	q.start.Do(func() { go q.run() })
+	select {
+	case q.ready <- struct{}{}:
+	default:
+		// the goroutine is already signaled
+	}
+}
+
+func (q *handlerQueue) run() {
+	for range q.ready {
+		q.Lock()
+		calls := q.calls
+		q.calls = nil
+		q.Unlock()
+		for _, call := range calls {
+			call()
+		}
+	}
+}
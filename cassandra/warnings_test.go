@@ -0,0 +1,123 @@
+package cassandra_test
+
+import (
+	"golang-driver/cassandra"
+	"golang-driver/cassandra/test"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestServerWarnings(t *testing.T) {
+	session := test.GetSession()
+	defer test.Shutdown()
+
+	if err := test.Setup(warningsSetup); err != nil {
+		t.Log("Unexpected error while setup. You might need to clean up manually golang_driver keyspace")
+		t.Fatal(err)
+	}
+	defer test.TearDown(warningsCleanup)
+
+	// the handler is called from another goroutine, after the request
+	// completed
+	var mu sync.Mutex
+	var logged []string
+	session.SetWarningHandler(func(w string) {
+		mu.Lock()
+		logged = append(logged, w)
+		mu.Unlock()
+	})
+	defer session.SetWarningHandler(nil)
+	// returns the warnings handled once n of them were, or after a second
+	handled := func(n int) []string {
+		deadline := time.Now().Add(time.Second)
+		for {
+			mu.Lock()
+			warnings := logged
+			mu.Unlock()
+			if len(warnings) >= n || time.Now().After(deadline) {
+				return warnings
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	// Cassandra 3.0+ warns about aggregations without a partition key
+	rows, err := session.Exec("SELECT count(*) FROM golang_driver.warnings")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	if rows.Coordinator() == nil {
+		t.Error("the coordinator of the request should be known")
+	}
+	warnings := rows.Warnings()
+	if len(warnings) == 0 {
+		t.Fatal("expected an aggregation warning")
+	}
+	if !strings.Contains(strings.ToLower(warnings[0]), "aggregation") {
+		t.Errorf("unexpected warning: %s", warnings[0])
+	}
+	if got := handled(len(warnings)); len(got) != len(warnings) {
+		t.Errorf("warning handler got %d warnings != %d", len(got), len(warnings))
+	}
+	mu.Lock()
+	logged = nil
+	mu.Unlock()
+
+	// concurrent requests: the handler gets each logged warning once
+	const concurrent = 8
+	futures := make([]*cassandra.Future, concurrent)
+	for i := range futures {
+		futures[i] = session.ExecAsync("SELECT count(*) FROM golang_driver.warnings")
+	}
+	for _, future := range futures {
+		if err := future.Error(); err != nil {
+			t.Error(err)
+		}
+		future.Close()
+	}
+	if got := handled(concurrent); len(got) != concurrent {
+		t.Errorf("warning handler got %d warnings for %d requests", len(got), concurrent)
+	}
+
+	// a handler waiting for a request doesn't block the driver
+	done := make(chan error, 1)
+	var once sync.Once
+	session.SetWarningHandler(func(w string) {
+		once.Do(func() {
+			rows, err := session.Exec("SELECT key FROM system.local")
+			if err == nil {
+				rows.Close()
+			}
+			done <- err
+		})
+	})
+	rows, err = session.Exec("SELECT count(*) FROM golang_driver.warnings")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows.Close()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("the handler executing a request didn't complete")
+	}
+}
+
+var (
+	warningsSetup = []string{
+		"CREATE KEYSPACE IF NOT EXISTS golang_driver WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};",
+		"CREATE TABLE IF NOT EXISTS golang_driver.warnings (id int PRIMARY KEY, t text)",
+		"INSERT INTO golang_driver.warnings (id, t) VALUES (1, 'one')",
+	}
+
+	warningsCleanup = []string{
+		"DROP TABLE golang_driver.warnings",
+	}
+)
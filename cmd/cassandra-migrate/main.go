@@ -0,0 +1,114 @@
+// The cassandra-migrate command applies the versioned CQL scripts of a
+// directory to a cluster (see the migrate package):
+//
+//	cassandra-migrate -hosts 10.0.0.1,10.0.0.2 -keyspace shop -dir migrations up
+//	cassandra-migrate -keyspace shop -dir migrations -dry-run up
+//	cassandra-migrate -keyspace shop -dir migrations status
+package main
+
+import (
+	"flag"
+	"fmt"
+	"golang-driver/cassandra"
+	"golang-driver/cassandra/migrate"
+	"os"
+	"strings"
+	"time"
+)
+
+func main() {
+	hosts := flag.String("hosts", "127.0.0.1", "the comma separated contact points")
+	port := flag.Int("port", 0, "the port of the nodes (0 for 9042)")
+	keyspace := flag.String("keyspace", "", "the keyspace of the tracking table (required)")
+	table := flag.String("table", "schema_migrations", "the tracking table")
+	dir := flag.String("dir", ".", "the directory of the <version>_<name>.cql scripts")
+	dryRun := flag.Bool("dry-run", false, "print the pending statements instead of executing them")
+	lockTimeout := flag.Duration("lock-timeout", time.Minute, "how long to wait for a concurrent run")
+	schemaTimeout := flag.Duration("schema-timeout", 30*time.Second, "how long to wait for the schema agreement")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] [up|status]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	command := "up"
+	if flag.NArg() > 0 {
+		command = flag.Arg(0)
+	}
+	if flag.NArg() > 1 || (command != "up" && command != "status") || *keyspace == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(command, *hosts, *port, *dir, migrate.Options{
+		Keyspace:               *keyspace,
+		Table:                  *table,
+		DryRun:                 *dryRun,
+		Out:                    os.Stdout,
+		LockTimeout:            *lockTimeout,
+		SchemaAgreementTimeout: *schemaTimeout,
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(command, hosts string, port int, dir string, opts migrate.Options) error {
+	migrations, err := migrate.Load(os.DirFS(dir))
+	if err != nil {
+		return err
+	}
+
+	cluster := cassandra.NewCluster(strings.Split(hosts, ",")...)
+	defer cluster.Close()
+	if port != 0 {
+		if err := cluster.SetPort(port); err != nil {
+			return err
+		}
+	}
+	session, err := cluster.Connect()
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+
+	m, err := migrate.New(session, opts)
+	if err != nil {
+		return err
+	}
+	if command == "status" {
+		return status(m, migrations)
+	}
+	applied, err := m.Up(migrations)
+	if err != nil {
+		return err
+	}
+	if !opts.DryRun {
+		fmt.Printf("%d migration(s) applied\n", len(applied))
+	}
+	return nil
+}
+
+// prints the applied and pending migrations
+func status(m *migrate.Migrator, migrations []migrate.Migration) error {
+	applied, err := m.Applied()
+	if err != nil {
+		return err
+	}
+	checksums := make(map[int64]string, len(applied))
+	for _, a := range applied {
+		checksums[a.Version] = a.Checksum
+		fmt.Printf("applied  %d %s (%s)\n", a.Version, a.Name, a.AppliedAt.Format(time.RFC3339))
+	}
+	for _, migration := range migrations {
+		checksum, ok := checksums[migration.Version]
+		switch {
+		case !ok:
+			fmt.Printf("pending  %s\n", migration.String())
+		case checksum != migration.Checksum:
+			fmt.Printf("changed  %s\n", migration.String())
+		}
+	}
+	_, err = migrate.Pending(migrations, applied)
+	return err
+}